@@ -16,7 +16,10 @@
 
 package collections
 
-import "fmt"
+import (
+	"cmp"
+	"fmt"
+)
 
 type Comparable interface {
 	// Compare should return:
@@ -26,22 +29,97 @@ type Comparable interface {
 	Compare(other Comparable) int
 }
 
-type node[T Comparable] struct {
+type node[T any] struct {
 	lft    *node[T]
 	rgt    *node[T]
-	parent *node[T]
-	value  Comparable
+	value  T
+	height int
+	size   int
 }
 
-func (node *node[T]) isLeaf() bool {
-	return node.lft == nil && node.rgt == nil
+func (node *node[T]) getHeight() int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+// getSize returns number of items in the subtree
+// with the node as its root
+func (node *node[T]) getSize() int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+// update recalculates height and size of the node based
+// on its (already up to date) children
+func (node *node[T]) update() {
+	node.height = 1 + max(node.lft.getHeight(), node.rgt.getHeight())
+	node.size = 1 + node.lft.getSize() + node.rgt.getSize()
+}
+
+func (node *node[T]) balanceFactor() int {
+	return node.lft.getHeight() - node.rgt.getHeight()
+}
+
+func (node *node[T]) rotateLeft() *node[T] {
+	newRoot := node.rgt
+	node.rgt = newRoot.lft
+	newRoot.lft = node
+	node.update()
+	newRoot.update()
+	return newRoot
+}
+
+func (node *node[T]) rotateRight() *node[T] {
+	newRoot := node.lft
+	node.lft = newRoot.rgt
+	newRoot.rgt = node
+	node.update()
+	newRoot.update()
+	return newRoot
 }
 
-// BinTree is a simple unbalanced binary tree
-// implementation for storing sorted values
+// rebalance updates the node and performs AVL rotations
+// in case its subtrees heights differ by more than one.
+// The returned value is the new root of the subtree.
+func (node *node[T]) rebalance() *node[T] {
+	node.update()
+	bf := node.balanceFactor()
+	if bf > 1 {
+		if node.lft.balanceFactor() < 0 {
+			node.lft = node.lft.rotateLeft()
+		}
+		return node.rotateRight()
+
+	} else if bf < -1 {
+		if node.rgt.balanceFactor() > 0 {
+			node.rgt = node.rgt.rotateRight()
+		}
+		return node.rotateLeft()
+	}
+	return node
+}
+
+// binTreeCore is a self-balancing (AVL) binary tree
+// implementation shared by BinTree and BinTreeFunc.
+// Each node keeps the size of its subtree so accessing
+// and removing items by their index is O(log n) even
+// in case the values are inserted already sorted.
+type binTreeCore[T any] struct {
+	root *node[T]
+	cmp  func(a, b T) int
+}
+
+// BinTree is a self-balancing (AVL) binary tree
+// implementation for storing sorted values.
+// The values must implement the Comparable interface.
+// For primitive types or custom comparison functions,
+// see BinTreeFunc.
 type BinTree[T Comparable] struct {
-	root   *node[T]
-	length int
+	binTreeCore[T]
 
 	// UniqValues if true than the tree won't allow adding
 	// duplicate items (in terms of their `Compare` results)
@@ -55,41 +133,82 @@ type BinTree[T Comparable] struct {
 // Calling the function without arguments is
 // considered a no-op.
 func (bt *BinTree[T]) Add(v ...T) {
+	if bt.cmp == nil {
+		bt.cmp = func(a, b T) int {
+			return a.Compare(b)
+		}
+	}
 	for _, vx := range v {
-		bt.add(vx)
+		bt.root, _ = bt.insert(bt.root, vx, bt.UniqValues)
 	}
 }
 
-func (bt *BinTree[T]) add(v T) {
-	defer func() { bt.length++ }()
-	if bt.root == nil {
-		bt.root = &node[T]{value: v}
-		return
+// BinTreeFunc is a self-balancing (AVL) binary tree
+// implementation for storing sorted values of any type.
+// The order of values is given by a comparison function
+// with the same rules as in slices.SortFunc.
+// Please use NewBinTreeFunc or NewOrderedBinTree to create
+// an instance.
+type BinTreeFunc[T any] struct {
+	binTreeCore[T]
+
+	// UniqValues if true than the tree won't allow adding
+	// duplicate items (in terms of the comparison function)
+	// It can be enabled at any time during operation. The
+	// effect then starts with the next call of the Add method.
+	// The same applies for setting the value back to false.
+	UniqValues bool
+}
+
+// Add adds zero or more items to the tree.
+// Calling the function without arguments is
+// considered a no-op.
+func (bt *BinTreeFunc[T]) Add(v ...T) {
+	for _, vx := range v {
+		bt.root, _ = bt.insert(bt.root, vx, bt.UniqValues)
 	}
-	currNode := bt.root
-	for currNode != nil {
-		cmp := v.Compare(currNode.value)
-		if bt.UniqValues && cmp == 0 {
-			return
-		}
-		if cmp <= 0 {
-			if currNode.lft == nil {
-				currNode.lft = &node[T]{value: v, parent: currNode}
-				return
-			}
-			currNode = currNode.lft
+}
 
-		} else {
-			if currNode.rgt == nil {
-				currNode.rgt = &node[T]{value: v, parent: currNode}
-				return
-			}
-			currNode = currNode.rgt
-		}
+// NewBinTreeFunc creates a new BinTreeFunc with values ordered
+// by the provided comparison function (the rules for the function
+// are the same as in slices.SortFunc).
+func NewBinTreeFunc[T any](cmp func(a, b T) int) *BinTreeFunc[T] {
+	return &BinTreeFunc[T]{binTreeCore: binTreeCore[T]{cmp: cmp}}
+}
+
+// NewOrderedBinTree creates a new BinTreeFunc for ordered
+// types (ints, floats, strings) with values sorted in ascending
+// order.
+func NewOrderedBinTree[T cmp.Ordered]() *BinTreeFunc[T] {
+	return NewBinTreeFunc(cmp.Compare[T])
+}
+
+// insert adds a new value to the subtree starting with `root`
+// and returns the new (possibly rotated) root of the subtree
+// along with information whether the value has been actually
+// inserted.
+func (bt *binTreeCore[T]) insert(root *node[T], v T, uniq bool) (*node[T], bool) {
+	if root == nil {
+		return &node[T]{value: v, height: 1, size: 1}, true
 	}
+	cmp := bt.cmp(v, root.value)
+	if uniq && cmp == 0 {
+		return root, false
+	}
+	var inserted bool
+	if cmp <= 0 {
+		root.lft, inserted = bt.insert(root.lft, v, uniq)
+
+	} else {
+		root.rgt, inserted = bt.insert(root.rgt, v, uniq)
+	}
+	if !inserted {
+		return root, false
+	}
+	return root.rebalance(), true
 }
 
-func (BinTree[T]) goLeftmost(root *node[T], stack []*node[T]) []*node[T] {
+func (binTreeCore[T]) goLeftmost(root *node[T], stack []*node[T]) []*node[T] {
 	curr := root.lft
 	for curr != nil {
 		stack = append(stack, curr)
@@ -98,108 +217,92 @@ func (BinTree[T]) goLeftmost(root *node[T], stack []*node[T]) []*node[T] {
 	return stack
 }
 
-// findNodeAt returns node at position `idx` along with its parent
-func (bt *BinTree[T]) findNodeAt(idx int) *node[T] {
-	stack := []*node[T]{bt.root}
-	stack = bt.goLeftmost(bt.root, stack)
-	var i int
-	for len(stack) > 0 {
-		node := stack[len(stack)-1]
-		stack = stack[:len(stack)-1]
+// findNodeAt returns node at position `idx` or nil
+// if there is no such position
+func (bt *binTreeCore[T]) findNodeAt(idx int) *node[T] {
+	curr := bt.root
+	for curr != nil {
+		lsize := curr.lft.getSize()
+		if idx < lsize {
+			curr = curr.lft
 
-		if i == idx {
-			return node
-		}
-		i++
-		if node.rgt != nil {
-			stack = append(stack, node.rgt)
-			stack = bt.goLeftmost(node.rgt, stack)
+		} else if idx > lsize {
+			idx -= lsize + 1
+			curr = curr.rgt
+
+		} else {
+			return curr
 		}
 	}
 	return nil
 }
 
-func (bt BinTree[T]) ToSlice() []T {
-	if bt.length == 0 {
+func (bt binTreeCore[T]) ToSlice() []T {
+	if bt.root == nil {
 		return []T{}
 	}
-	ans := make([]T, 0, bt.length)
-	stack := []*node[T]{bt.root}
-	stack = bt.goLeftmost(bt.root, stack)
-	for len(stack) > 0 {
-		node := stack[len(stack)-1]
-		stack = stack[:len(stack)-1]
-
-		ans = append(ans, node.value.(T))
-		if node.rgt != nil {
-			stack = append(stack, node.rgt)
-			stack = bt.goLeftmost(node.rgt, stack)
-		}
+	ans := make([]T, 0, bt.root.size)
+	for _, v := range bt.Iterate {
+		ans = append(ans, v)
 	}
 	return ans
 }
 
-func (bt *BinTree[T]) Remove(idx int) T {
-	if bt.length == 0 {
-		panic(fmt.Sprintf("BinTree index overflow: %d (len: %d)", idx, bt.length))
+// removeMin removes the leftmost node of the subtree
+// and returns the new root of the subtree along with
+// the removed node.
+func (bt *binTreeCore[T]) removeMin(root *node[T]) (*node[T], *node[T]) {
+	if root.lft == nil {
+		return root.rgt, root
 	}
-	srch := bt.findNodeAt(idx)
-	if srch == nil {
-		var zeroVal T
-		return zeroVal
-	}
-	rtrn := srch.value.(T)
-	defer func() { bt.length-- }()
-	if srch.isLeaf() {
-		if srch.parent != nil {
-			if srch.parent.lft == srch {
-				srch.parent.lft = nil
-
-			} else {
-				srch.parent.rgt = nil
-			}
-		}
-
-	} else if srch.lft == nil && srch.rgt != nil {
-		if srch.parent != nil {
-			if srch.parent.lft == srch {
-				srch.parent.lft = srch.rgt
-				srch.rgt.parent = srch.parent
-
-			} else {
-				srch.parent.rgt = srch.rgt
-				srch.rgt.parent = srch.parent
-			}
-		}
+	var minNode *node[T]
+	root.lft, minNode = bt.removeMin(root.lft)
+	return root.rebalance(), minNode
+}
 
-	} else if srch.lft != nil && srch.rgt == nil {
-		if srch.parent != nil {
-			if srch.parent.lft == srch {
-				srch.parent.lft = srch.lft
-				srch.lft.parent = srch.parent
+// removeAt removes an item at the position `idx` (relative
+// to the subtree) and returns the new root of the subtree
+// along with the removed value. The `idx` must be within
+// the subtree range.
+func (bt *binTreeCore[T]) removeAt(root *node[T], idx int) (*node[T], T) {
+	var removed T
+	lsize := root.lft.getSize()
+	if idx < lsize {
+		root.lft, removed = bt.removeAt(root.lft, idx)
 
-			} else {
-				srch.parent.rgt = srch.lft
-				srch.lft.parent = srch.parent
-			}
-		}
+	} else if idx > lsize {
+		root.rgt, removed = bt.removeAt(root.rgt, idx-lsize-1)
 
 	} else {
-		succ := srch.rgt
-		succParent := srch
-		for succ.lft != nil {
-			succParent = succ
-			succ = succ.lft
-		}
-		if succParent != srch {
-			succParent.lft = succ.rgt
+		removed = root.value
+		if root.lft == nil {
+			return root.rgt, removed
 
-		} else {
-			succParent.rgt = succ.rgt
+		} else if root.rgt == nil {
+			return root.lft, removed
 		}
-		srch.value = succ.value
+		var succ *node[T]
+		root.rgt, succ = bt.removeMin(root.rgt)
+		root.value = succ.value
 	}
-	return rtrn
+	return root.rebalance(), removed
+}
+
+// Remove removes an item on i-th index and returns it.
+// In case the tree is empty, the function panics.
+// For an index out of range, zero value of T is returned
+// and the tree is not modified.
+func (bt *binTreeCore[T]) Remove(idx int) T {
+	if bt.Len() == 0 {
+		panic(fmt.Sprintf("BinTree index overflow: %d (len: %d)", idx, bt.Len()))
+	}
+	if idx < 0 || idx >= bt.Len() {
+		var zeroVal T
+		return zeroVal
+	}
+	var ans T
+	bt.root, ans = bt.removeAt(bt.root, idx)
+	return ans
 }
 
 // Get returns an item on i-th index. The function
@@ -207,32 +310,31 @@ func (bt *BinTree[T]) Remove(idx int) T {
 // item.
 // In case the index does not exist in data the function
 // panics.
-func (bt *BinTree[T]) Get(idx int) T {
-	if bt.length == 0 {
-		panic(fmt.Sprintf("BinTree index overflow: %d (len: %d)", idx, bt.length))
+func (bt *binTreeCore[T]) Get(idx int) T {
+	if bt.Len() == 0 {
+		panic(fmt.Sprintf("BinTree index overflow: %d (len: %d)", idx, bt.Len()))
 	}
 	rIdx := idx
 	if idx < 0 {
-		rIdx = bt.length + idx
+		rIdx = bt.Len() + idx
 	}
 	srch := bt.findNodeAt(rIdx)
 	if srch != nil {
-		ans, _ := srch.value.(T)
-		return ans
+		return srch.value
 	}
-	panic(fmt.Sprintf("BinTree index overflow: %d (len: %d)", idx, bt.length))
+	panic(fmt.Sprintf("BinTree index overflow: %d (len: %d)", idx, bt.Len()))
 }
 
-func (bt *BinTree[T]) Len() int {
-	return bt.length
+func (bt *binTreeCore[T]) Len() int {
+	return bt.root.getSize()
 }
 
-func (bt *BinTree[T]) ForEach(fn func(i int, v T) bool) {
+func (bt *binTreeCore[T]) ForEach(fn func(i int, v T) bool) {
 	bt.Iterate(fn)
 }
 
-func (bt *BinTree[T]) Iterate(yield func(i int, v T) bool) {
-	if bt.length == 0 {
+func (bt *binTreeCore[T]) Iterate(yield func(i int, v T) bool) {
+	if bt.root == nil {
 		return
 	}
 	stack := []*node[T]{bt.root}
@@ -242,7 +344,7 @@ func (bt *BinTree[T]) Iterate(yield func(i int, v T) bool) {
 		node := stack[len(stack)-1]
 		stack = stack[:len(stack)-1]
 
-		if !yield(i, node.value.(T)) {
+		if !yield(i, node.value) {
 			break
 		}
 		i++
@@ -253,3 +355,151 @@ func (bt *BinTree[T]) Iterate(yield func(i int, v T) bool) {
 		}
 	}
 }
+
+func (binTreeCore[T]) goRightmost(root *node[T], stack []*node[T]) []*node[T] {
+	curr := root.rgt
+	for curr != nil {
+		stack = append(stack, curr)
+		curr = curr.rgt
+	}
+	return stack
+}
+
+// IterateReverse goes through all the items from the greatest
+// one to the lowest one. The index passed to `yield` is the item's
+// position in the tree (i.e. it starts with Len() - 1).
+func (bt *binTreeCore[T]) IterateReverse(yield func(i int, v T) bool) {
+	if bt.root == nil {
+		return
+	}
+	stack := []*node[T]{bt.root}
+	stack = bt.goRightmost(bt.root, stack)
+	i := bt.Len() - 1
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if !yield(i, node.value) {
+			break
+		}
+		i--
+
+		if node.lft != nil {
+			stack = append(stack, node.lft)
+			stack = bt.goRightmost(node.lft, stack)
+		}
+	}
+}
+
+// RangeIter returns an iterator over all the items `v` for which
+// lo <= v <= hi holds. The items are produced lazily in ascending
+// order along with their position in the tree.
+func (bt *binTreeCore[T]) RangeIter(lo, hi T) func(yield func(i int, v T) bool) {
+	return func(yield func(i int, v T) bool) {
+		stack := make([]*node[T], 0, bt.root.getHeight())
+		curr := bt.root
+		for curr != nil {
+			if bt.cmp(curr.value, lo) >= 0 {
+				stack = append(stack, curr)
+				curr = curr.lft
+
+			} else {
+				curr = curr.rgt
+			}
+		}
+		i := bt.Rank(lo)
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			if bt.cmp(node.value, hi) > 0 || !yield(i, node.value) {
+				break
+			}
+			i++
+
+			if node.rgt != nil {
+				stack = append(stack, node.rgt)
+				stack = bt.goLeftmost(node.rgt, stack)
+			}
+		}
+	}
+}
+
+// Find searches for an item equal (in terms of the tree ordering) to `v`.
+// In case there are more such items, any of them can be returned.
+func (bt *binTreeCore[T]) Find(v T) (T, bool) {
+	curr := bt.root
+	for curr != nil {
+		cmp := bt.cmp(v, curr.value)
+		if cmp < 0 {
+			curr = curr.lft
+
+		} else if cmp > 0 {
+			curr = curr.rgt
+
+		} else {
+			return curr.value, true
+		}
+	}
+	var zeroVal T
+	return zeroVal, false
+}
+
+// Floor returns the greatest item lesser or equal to `v`.
+// If there is no such item, false is returned as the second value.
+func (bt *binTreeCore[T]) Floor(v T) (T, bool) {
+	var ans *node[T]
+	curr := bt.root
+	for curr != nil {
+		if bt.cmp(curr.value, v) <= 0 {
+			ans = curr
+			curr = curr.rgt
+
+		} else {
+			curr = curr.lft
+		}
+	}
+	if ans == nil {
+		var zeroVal T
+		return zeroVal, false
+	}
+	return ans.value, true
+}
+
+// Ceiling returns the lowest item greater or equal to `v`.
+// If there is no such item, false is returned as the second value.
+func (bt *binTreeCore[T]) Ceiling(v T) (T, bool) {
+	var ans *node[T]
+	curr := bt.root
+	for curr != nil {
+		if bt.cmp(curr.value, v) >= 0 {
+			ans = curr
+			curr = curr.lft
+
+		} else {
+			curr = curr.rgt
+		}
+	}
+	if ans == nil {
+		var zeroVal T
+		return zeroVal, false
+	}
+	return ans.value, true
+}
+
+// Rank returns number of items lesser than `v`. In other words,
+// it is the index `v` would be inserted at.
+func (bt *binTreeCore[T]) Rank(v T) int {
+	var ans int
+	curr := bt.root
+	for curr != nil {
+		if bt.cmp(curr.value, v) < 0 {
+			ans += curr.lft.getSize() + 1
+			curr = curr.rgt
+
+		} else {
+			curr = curr.lft
+		}
+	}
+	return ans
+}
@@ -227,3 +227,182 @@ func TestUniqueSetting(t *testing.T) {
 	bt.Add(3)
 	assert.Equal(t, []myInt{1, 1, 2, 3}, bt.ToSlice())
 }
+
+func TestBinTreeUniqueSettingLen(t *testing.T) {
+	var bt BinTree[myInt]
+	bt.UniqValues = true
+	bt.Add(2, 1, 3, 2, 1)
+	assert.Equal(t, 3, bt.Len())
+}
+
+func TestBinTreeStaysBalancedOnSortedInput(t *testing.T) {
+	var bt BinTree[myInt]
+	for i := 0; i < 1024; i++ {
+		bt.Add(myInt(i))
+	}
+	assert.Equal(t, 1024, bt.Len())
+	// AVL tree height is at most ~1.44 * log2(n)
+	assert.LessOrEqual(t, bt.root.height, 15)
+	for i := 0; i < 1024; i++ {
+		assert.Equal(t, myInt(i), bt.Get(i))
+	}
+}
+
+func TestBinTreeStaysBalancedOnRemove(t *testing.T) {
+	var bt BinTree[myInt]
+	for i := 1023; i >= 0; i-- {
+		bt.Add(myInt(i))
+	}
+	for i := 0; i < 512; i++ {
+		assert.Equal(t, myInt(i*2), bt.Remove(i))
+	}
+	assert.Equal(t, 512, bt.Len())
+	assert.LessOrEqual(t, bt.root.height, 14)
+	for i := 0; i < 512; i++ {
+		assert.Equal(t, myInt(i*2+1), bt.Get(i))
+	}
+}
+
+func TestBinTreeRemoveRoot(t *testing.T) {
+	var bt BinTree[myInt]
+	bt.Add(10)
+	assert.Equal(t, myInt(10), bt.Remove(0))
+	assert.Equal(t, 0, bt.Len())
+	assert.Equal(t, []myInt{}, bt.ToSlice())
+}
+
+func TestBinTreeRemoveOverflow(t *testing.T) {
+	var bt BinTree[myInt]
+	bt.Add(10, 20, 8)
+	assert.Equal(t, myInt(0), bt.Remove(3))
+	assert.Equal(t, 3, bt.Len())
+}
+
+func TestBinTreeFind(t *testing.T) {
+	var bt BinTree[myInt]
+	bt.Add(10, 20, 8, 15, 4, 21, 20)
+	v, ok := bt.Find(15)
+	assert.True(t, ok)
+	assert.Equal(t, myInt(15), v)
+	_, ok = bt.Find(16)
+	assert.False(t, ok)
+}
+
+func TestBinTreeFindOnEmpty(t *testing.T) {
+	var bt BinTree[myInt]
+	_, ok := bt.Find(16)
+	assert.False(t, ok)
+}
+
+func TestBinTreeFloorCeiling(t *testing.T) {
+	var bt BinTree[myInt]
+	// 4, 8, 10, 15, 20, 20, 21
+	bt.Add(10, 20, 8, 15, 4, 21, 20)
+
+	v, ok := bt.Floor(16)
+	assert.True(t, ok)
+	assert.Equal(t, myInt(15), v)
+	v, ok = bt.Floor(15)
+	assert.True(t, ok)
+	assert.Equal(t, myInt(15), v)
+	_, ok = bt.Floor(3)
+	assert.False(t, ok)
+
+	v, ok = bt.Ceiling(16)
+	assert.True(t, ok)
+	assert.Equal(t, myInt(20), v)
+	v, ok = bt.Ceiling(4)
+	assert.True(t, ok)
+	assert.Equal(t, myInt(4), v)
+	_, ok = bt.Ceiling(22)
+	assert.False(t, ok)
+}
+
+func TestBinTreeRank(t *testing.T) {
+	var bt BinTree[myInt]
+	// 4, 8, 10, 15, 20, 20, 21
+	bt.Add(10, 20, 8, 15, 4, 21, 20)
+	assert.Equal(t, 0, bt.Rank(1))
+	assert.Equal(t, 0, bt.Rank(4))
+	assert.Equal(t, 3, bt.Rank(15))
+	assert.Equal(t, 4, bt.Rank(20))
+	assert.Equal(t, 6, bt.Rank(21))
+	assert.Equal(t, 7, bt.Rank(100))
+}
+
+func TestBinTreeRangeIter(t *testing.T) {
+	var bt BinTree[myInt]
+	// 4, 8, 10, 15, 20, 20, 21
+	bt.Add(10, 20, 8, 15, 4, 21, 20)
+	iTest := make([]int, 0, 4)
+	vTest := make([]myInt, 0, 4)
+	for i, v := range bt.RangeIter(9, 20) {
+		iTest = append(iTest, i)
+		vTest = append(vTest, v)
+	}
+	assert.Equal(t, []int{2, 3, 4, 5}, iTest)
+	assert.Equal(t, []myInt{10, 15, 20, 20}, vTest)
+}
+
+func TestBinTreeRangeIterEmptyRange(t *testing.T) {
+	var bt BinTree[myInt]
+	bt.Add(10, 20, 8, 15, 4, 21, 20)
+	var cnt int
+	for range bt.RangeIter(11, 14) {
+		cnt++
+	}
+	assert.Equal(t, 0, cnt)
+	for range bt.RangeIter(30, 10) {
+		cnt++
+	}
+	assert.Equal(t, 0, cnt)
+}
+
+func TestBinTreeIterateReverse(t *testing.T) {
+	var bt BinTree[myInt]
+	bt.Add(30, 20, 40, 10)
+	iTest := make([]int, 0, 4)
+	vTest := make([]myInt, 0, 4)
+	for i, v := range bt.IterateReverse {
+		iTest = append(iTest, i)
+		vTest = append(vTest, v)
+	}
+	assert.Equal(t, []int{3, 2, 1, 0}, iTest)
+	assert.Equal(t, []myInt{40, 30, 20, 10}, vTest)
+}
+
+func TestOrderedBinTree(t *testing.T) {
+	bt := NewOrderedBinTree[int]()
+	bt.Add(10, 20, 8, 15, 4, 21, 20)
+	assert.Equal(t, []int{4, 8, 10, 15, 20, 20, 21}, bt.ToSlice())
+	assert.Equal(t, 21, bt.Get(-1))
+	assert.Equal(t, 3, bt.Rank(15))
+	v, ok := bt.Floor(16)
+	assert.True(t, ok)
+	assert.Equal(t, 15, v)
+}
+
+func TestOrderedBinTreeStrings(t *testing.T) {
+	bt := NewOrderedBinTree[string]()
+	bt.UniqValues = true
+	bt.Add("foo", "bar", "baz", "foo")
+	assert.Equal(t, 3, bt.Len())
+	assert.Equal(t, "bar", bt.Remove(0))
+	assert.Equal(t, []string{"baz", "foo"}, bt.ToSlice())
+}
+
+func TestBinTreeFuncCustomOrder(t *testing.T) {
+	bt := NewBinTreeFunc(func(a, b groupable) int {
+		return b.ID - a.ID
+	})
+	bt.Add(
+		groupable{Type: "A", ID: 1},
+		groupable{Type: "B", ID: 3},
+		groupable{Type: "C", ID: 2},
+	)
+	vTest := make([]string, 0, 3)
+	for _, v := range bt.Iterate {
+		vTest = append(vTest, v.Type)
+	}
+	assert.Equal(t, []string{"B", "C", "A"}, vTest)
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// doubleHash calculates two independent 64-bit hashes of `data`
+// which are then combined (h1 + i*h2) to obtain any number of hash
+// functions (Kirsch-Mitzenmacher). FNV is used (instead of maphash)
+// so the values are stable across processes which makes serialized
+// structures shareable between service instances.
+func doubleHash(data []byte) (uint64, uint64) {
+	hasher := fnv.New128a()
+	hasher.Write(data)
+	sum := hasher.Sum(nil)
+	return binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:]) | 1
+}
+
+// BloomFilter is a probabilistic set which may report false positives
+// (with a configured probability) but never false negatives.
+// The filter is not concurrency-safe.
+type BloomFilter struct {
+	bits     []uint64
+	numBits  uint64
+	numHash  uint64
+	numAdded uint64
+}
+
+func (bf *BloomFilter) position(h1, h2 uint64, i uint64) (uint64, uint64) {
+	pos := (h1 + i*h2) % bf.numBits
+	return pos / 64, pos % 64
+}
+
+// Add adds a value to the filter
+func (bf *BloomFilter) Add(data []byte) {
+	h1, h2 := doubleHash(data)
+	for i := uint64(0); i < bf.numHash; i++ {
+		word, bit := bf.position(h1, h2, i)
+		bf.bits[word] |= 1 << bit
+	}
+	bf.numAdded++
+}
+
+// AddString adds a string value to the filter
+func (bf *BloomFilter) AddString(s string) {
+	bf.Add([]byte(s))
+}
+
+// Test tests whether the value may be in the filter. The false
+// value means the value has definitely not been added.
+func (bf *BloomFilter) Test(data []byte) bool {
+	h1, h2 := doubleHash(data)
+	for i := uint64(0); i < bf.numHash; i++ {
+		word, bit := bf.position(h1, h2, i)
+		if bf.bits[word]&(1<<bit) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TestString tests whether the string value may be in the filter
+func (bf *BloomFilter) TestString(s string) bool {
+	return bf.Test([]byte(s))
+}
+
+// TestAndAdd tests whether the value may be in the filter
+// and adds it afterwards (e.g. for deduplication).
+func (bf *BloomFilter) TestAndAdd(data []byte) bool {
+	ans := bf.Test(data)
+	bf.Add(data)
+	return ans
+}
+
+// NumAdded returns number of Add calls (including the values
+// added to merged filters). Repeated values are counted repeatedly.
+func (bf *BloomFilter) NumAdded() uint64 {
+	return bf.numAdded
+}
+
+// NumBits returns the size of the filter in bits
+func (bf *BloomFilter) NumBits() uint64 {
+	return bf.numBits
+}
+
+// NumHashFunctions returns number of hash functions the filter uses
+func (bf *BloomFilter) NumHashFunctions() uint64 {
+	return bf.numHash
+}
+
+// FalsePositiveRate estimates the current probability of false positives
+// based on the number of added values.
+func (bf *BloomFilter) FalsePositiveRate() float64 {
+	k := float64(bf.numHash)
+	return math.Pow(1-math.Exp(-k*float64(bf.numAdded)/float64(bf.numBits)), k)
+}
+
+// Merge adds all the values from the `other` filter. Both the filters
+// must have the same parameters (i.e. they must have been created
+// with the same expected number of items and false positive rate).
+func (bf *BloomFilter) Merge(other *BloomFilter) error {
+	if bf.numBits != other.numBits || bf.numHash != other.numHash {
+		return fmt.Errorf(
+			"failed to merge BloomFilter: incompatible parameters (bits: %d vs. %d, hash functions: %d vs. %d)",
+			bf.numBits, other.numBits, bf.numHash, other.numHash,
+		)
+	}
+	for i, v := range other.bits {
+		bf.bits[i] |= v
+	}
+	bf.numAdded += other.numAdded
+	return nil
+}
+
+// Reset removes all the values from the filter
+func (bf *BloomFilter) Reset() {
+	clear(bf.bits)
+	bf.numAdded = 0
+}
+
+func (bf *BloomFilter) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := gob.NewEncoder(&buf)
+	if err := encoder.Encode(bf.bits); err != nil {
+		return []byte{}, fmt.Errorf("failed to GOB encode BloomFilter: %w", err)
+	}
+	if err := encoder.Encode(bf.numBits); err != nil {
+		return []byte{}, fmt.Errorf("failed to GOB encode BloomFilter: %w", err)
+	}
+	if err := encoder.Encode(bf.numHash); err != nil {
+		return []byte{}, fmt.Errorf("failed to GOB encode BloomFilter: %w", err)
+	}
+	if err := encoder.Encode(bf.numAdded); err != nil {
+		return []byte{}, fmt.Errorf("failed to GOB encode BloomFilter: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (bf *BloomFilter) GobDecode(data []byte) error {
+	buf := bytes.NewBuffer(data)
+	decoder := gob.NewDecoder(buf)
+	if err := decoder.Decode(&bf.bits); err != nil {
+		return fmt.Errorf("failed to GOB decode BloomFilter: %w", err)
+	}
+	if err := decoder.Decode(&bf.numBits); err != nil {
+		return fmt.Errorf("failed to GOB decode BloomFilter: %w", err)
+	}
+	if err := decoder.Decode(&bf.numHash); err != nil {
+		return fmt.Errorf("failed to GOB decode BloomFilter: %w", err)
+	}
+	if err := decoder.Decode(&bf.numAdded); err != nil {
+		return fmt.Errorf("failed to GOB decode BloomFilter: %w", err)
+	}
+	if bf.numBits == 0 || bf.numHash == 0 {
+		return fmt.Errorf("failed to GOB decode BloomFilter: invalid number of bits or hash functions")
+	}
+	if uint64(len(bf.bits)) != (bf.numBits+63)/64 {
+		return fmt.Errorf("failed to GOB decode BloomFilter: inconsistent data size")
+	}
+	return nil
+}
+
+// NewBloomFilter creates a new BloomFilter sized for `expectedItems`
+// values so that the false positive probability does not exceed
+// `falsePositiveRate` (e.g. 0.01) once the filter is filled with
+// the expected number of values.
+// The function panics if expectedItems is less than 1 or if
+// falsePositiveRate is not from the (0, 1) interval.
+func NewBloomFilter(expectedItems int, falsePositiveRate float64) *BloomFilter {
+	if expectedItems < 1 {
+		panic("NewBloomFilter - expectedItems must be at least 1")
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		panic("NewBloomFilter - falsePositiveRate must be from the (0, 1) interval")
+	}
+	n := float64(expectedItems)
+	numBits := uint64(math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	numHash := uint64(max(1, math.Round(float64(numBits)/n*math.Ln2)))
+	return &BloomFilter{
+		bits:    make([]uint64, (numBits+63)/64),
+		numBits: numBits,
+		numHash: numHash,
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBloomFilterParams(t *testing.T) {
+	bf := NewBloomFilter(1000, 0.01)
+	assert.Equal(t, uint64(9586), bf.NumBits())
+	assert.Equal(t, uint64(7), bf.NumHashFunctions())
+}
+
+func TestBloomFilterNoFalseNegatives(t *testing.T) {
+	bf := NewBloomFilter(1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		bf.AddString(fmt.Sprintf("item-%d", i))
+	}
+	for i := 0; i < 1000; i++ {
+		assert.True(t, bf.TestString(fmt.Sprintf("item-%d", i)))
+	}
+	assert.Equal(t, uint64(1000), bf.NumAdded())
+	assert.InDelta(t, 0.01, bf.FalsePositiveRate(), 0.002)
+}
+
+func TestBloomFilterFalsePositiveRate(t *testing.T) {
+	bf := NewBloomFilter(1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		bf.AddString(fmt.Sprintf("item-%d", i))
+	}
+	var numFP int
+	for i := 0; i < 10000; i++ {
+		if bf.TestString(fmt.Sprintf("other-%d", i)) {
+			numFP++
+		}
+	}
+	assert.Less(t, numFP, 200)
+}
+
+func TestBloomFilterTestAndAdd(t *testing.T) {
+	bf := NewBloomFilter(100, 0.01)
+	assert.False(t, bf.TestAndAdd([]byte("foo")))
+	assert.True(t, bf.TestAndAdd([]byte("foo")))
+	bf.Reset()
+	assert.False(t, bf.Test([]byte("foo")))
+	assert.Equal(t, uint64(0), bf.NumAdded())
+}
+
+func TestBloomFilterMerge(t *testing.T) {
+	bf1 := NewBloomFilter(100, 0.01)
+	bf2 := NewBloomFilter(100, 0.01)
+	bf1.AddString("foo")
+	bf2.AddString("bar")
+	assert.NoError(t, bf1.Merge(bf2))
+	assert.True(t, bf1.TestString("foo"))
+	assert.True(t, bf1.TestString("bar"))
+	assert.Equal(t, uint64(2), bf1.NumAdded())
+
+	bf3 := NewBloomFilter(200, 0.01)
+	assert.Error(t, bf1.Merge(bf3))
+}
+
+func TestBloomFilterGob(t *testing.T) {
+	bf := NewBloomFilter(100, 0.01)
+	bf.AddString("foo")
+	bf.AddString("bar")
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(bf))
+
+	var bf2 BloomFilter
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(&bf2))
+	assert.True(t, bf2.TestString("foo"))
+	assert.True(t, bf2.TestString("bar"))
+	assert.Equal(t, bf.NumBits(), bf2.NumBits())
+	assert.Equal(t, bf.NumHashFunctions(), bf2.NumHashFunctions())
+	assert.Equal(t, uint64(2), bf2.NumAdded())
+}
+
+func TestBloomFilterGobDecodeInvalidHeader(t *testing.T) {
+	for _, bf := range []*BloomFilter{
+		{bits: []uint64{}, numBits: 0, numHash: 3},
+		{bits: make([]uint64, 2), numBits: 128, numHash: 0},
+		{bits: make([]uint64, 1), numBits: 128, numHash: 3},
+	} {
+		data, err := bf.GobEncode()
+		assert.NoError(t, err)
+		var bf2 BloomFilter
+		assert.Error(t, bf2.GobDecode(data))
+	}
+}
+
+func TestBloomFilterInvalidParams(t *testing.T) {
+	assert.Panics(t, func() { NewBloomFilter(0, 0.01) })
+	assert.Panics(t, func() { NewBloomFilter(10, 0) })
+	assert.Panics(t, func() { NewBloomFilter(10, 1) })
+}
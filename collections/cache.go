@@ -0,0 +1,325 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+type CacheEvictionReason string
+
+const (
+	// CacheEvictionExpired means that the entry's TTL has passed
+	CacheEvictionExpired CacheEvictionReason = "expired"
+
+	// CacheEvictionCapacity means that the entry was the least recently
+	// used one when a new entry has been added to a full cache
+	CacheEvictionCapacity CacheEvictionReason = "capacity"
+)
+
+type cacheConf struct {
+	defaultTTL time.Duration
+	maxSize    int
+}
+
+// WithCacheTTL sets the time-to-live used by the Cache.Set method.
+// Zero value means entries never expire (which is also the default).
+func WithCacheTTL(value time.Duration) func(conf *cacheConf) {
+	return func(conf *cacheConf) {
+		conf.defaultTTL = value
+	}
+}
+
+// WithCacheMaxSize sets the maximum number of entries in the cache.
+// Once the limit is reached, adding a new entry evicts the least
+// recently used one. Zero value means no limit (which is also the default).
+func WithCacheMaxSize(value int) func(conf *cacheConf) {
+	return func(conf *cacheConf) {
+		conf.maxSize = value
+	}
+}
+
+// CacheStats contains usage statistics of a Cache
+type CacheStats struct {
+	Size        int   `json:"size"`
+	Hits        int64 `json:"hits"`
+	Misses      int64 `json:"misses"`
+	Evictions   int64 `json:"evictions"`
+	Expirations int64 `json:"expirations"`
+}
+
+type cacheEntry[K comparable, T any] struct {
+	key     K
+	value   T
+	expires time.Time
+}
+
+func (entry *cacheEntry[K, T]) isExpired(t time.Time) bool {
+	return !entry.expires.IsZero() && !t.Before(entry.expires)
+}
+
+// Cache is a concurrency-safe key-value cache with optional per-entry
+// TTL and optional maximum size with LRU eviction. Expired entries are
+// removed lazily on access or in bulk by a janitor (see StartJanitor).
+// Please use NewCache to create an instance.
+type Cache[K comparable, T any] struct {
+	mutex sync.Mutex
+	data  map[K]*list.Element
+
+	// lru contains *cacheEntry values with the most recently used
+	// entry at the front
+	lru         *list.List
+	conf        cacheConf
+	onEvict     func(k K, v T, reason CacheEvictionReason)
+	hits        int64
+	misses      int64
+	evictions   int64
+	expirations int64
+	now         func() time.Time
+}
+
+// removeElement removes the entry from the cache data and appends it
+// to the `evicted` slice so the eviction callback can be called
+// once the lock is released.
+func (c *Cache[K, T]) removeElement(
+	elm *list.Element,
+	evicted []cacheEntry[K, T],
+) []cacheEntry[K, T] {
+	entry := c.lru.Remove(elm).(*cacheEntry[K, T])
+	delete(c.data, entry.key)
+	return append(evicted, *entry)
+}
+
+// notifyEvicted calls the eviction callback for all the evicted entries.
+// The callback must be read from the cache while its lock is held.
+func notifyEvicted[K comparable, T any](
+	onEvict func(k K, v T, reason CacheEvictionReason),
+	evicted []cacheEntry[K, T],
+	reason CacheEvictionReason,
+) {
+	if onEvict == nil {
+		return
+	}
+	for _, entry := range evicted {
+		onEvict(entry.key, entry.value, reason)
+	}
+}
+
+// OnEviction sets a function called whenever an entry is removed
+// from the cache due to its expiration or due to the capacity limit.
+// Explicit removals via Delete do not trigger the function.
+// The function is called outside of the cache's internal lock so it
+// is safe to access the cache from within it.
+func (c *Cache[K, T]) OnEviction(fn func(k K, v T, reason CacheEvictionReason)) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.onEvict = fn
+}
+
+// GetWithTest returns a value stored under the key `k` along with
+// information whether the key is present (and not expired).
+// A successful lookup marks the entry as the most recently used one.
+func (c *Cache[K, T]) GetWithTest(k K) (T, bool) {
+	var evicted []cacheEntry[K, T]
+	c.mutex.Lock()
+	elm, ok := c.data[k]
+	if ok {
+		entry := elm.Value.(*cacheEntry[K, T])
+		if entry.isExpired(c.now()) {
+			evicted = c.removeElement(elm, evicted)
+			c.expirations++
+
+		} else {
+			c.lru.MoveToFront(elm)
+			c.hits++
+			c.mutex.Unlock()
+			return entry.value, true
+		}
+	}
+	c.misses++
+	onEvict := c.onEvict
+	c.mutex.Unlock()
+	notifyEvicted(onEvict, evicted, CacheEvictionExpired)
+	var zeroVal T
+	return zeroVal, false
+}
+
+// Get returns a value stored under the key `k`. In case there is no
+// such (non-expired) key, zero value of T is returned.
+func (c *Cache[K, T]) Get(k K) T {
+	v, _ := c.GetWithTest(k)
+	return v
+}
+
+// Set stores a value with the default TTL (see WithCacheTTL)
+func (c *Cache[K, T]) Set(k K, v T) {
+	c.SetWithTTL(k, v, c.conf.defaultTTL)
+}
+
+// SetWithTTL stores a value which expires after `ttl`. Zero `ttl`
+// means the entry never expires.
+// In case the cache is full, the least recently used entry is evicted.
+func (c *Cache[K, T]) SetWithTTL(k K, v T, ttl time.Duration) {
+	var expires time.Time
+	if ttl > 0 {
+		expires = c.now().Add(ttl)
+	}
+	var evicted []cacheEntry[K, T]
+	c.mutex.Lock()
+	if elm, ok := c.data[k]; ok {
+		entry := elm.Value.(*cacheEntry[K, T])
+		entry.value = v
+		entry.expires = expires
+		c.lru.MoveToFront(elm)
+
+	} else {
+		c.data[k] = c.lru.PushFront(&cacheEntry[K, T]{key: k, value: v, expires: expires})
+		for c.conf.maxSize > 0 && c.lru.Len() > c.conf.maxSize {
+			evicted = c.removeElement(c.lru.Back(), evicted)
+			c.evictions++
+		}
+	}
+	onEvict := c.onEvict
+	c.mutex.Unlock()
+	notifyEvicted(onEvict, evicted, CacheEvictionCapacity)
+}
+
+// Delete removes the key `k` from the cache
+func (c *Cache[K, T]) Delete(k K) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if elm, ok := c.data[k]; ok {
+		c.lru.Remove(elm)
+		delete(c.data, k)
+	}
+}
+
+// Len returns number of entries in the cache. Please note that
+// the number may include expired entries not removed yet.
+func (c *Cache[K, T]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.data)
+}
+
+// Keys returns keys of all the non-expired entries ordered
+// from the most recently used one.
+func (c *Cache[K, T]) Keys() []K {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	t := c.now()
+	ans := make([]K, 0, len(c.data))
+	for elm := c.lru.Front(); elm != nil; elm = elm.Next() {
+		entry := elm.Value.(*cacheEntry[K, T])
+		if !entry.isExpired(t) {
+			ans = append(ans, entry.key)
+		}
+	}
+	return ans
+}
+
+// AsMap creates a shallow copy of all the non-expired entries
+func (c *Cache[K, T]) AsMap() map[K]T {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	t := c.now()
+	ans := make(map[K]T, len(c.data))
+	for k, elm := range c.data {
+		entry := elm.Value.(*cacheEntry[K, T])
+		if !entry.isExpired(t) {
+			ans[k] = entry.value
+		}
+	}
+	return ans
+}
+
+// Stats returns current usage statistics of the cache
+func (c *Cache[K, T]) Stats() CacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return CacheStats{
+		Size:        len(c.data),
+		Hits:        c.hits,
+		Misses:      c.misses,
+		Evictions:   c.evictions,
+		Expirations: c.expirations,
+	}
+}
+
+// DeleteExpired removes all the expired entries and returns
+// number of removed items.
+func (c *Cache[K, T]) DeleteExpired() int {
+	var evicted []cacheEntry[K, T]
+	c.mutex.Lock()
+	t := c.now()
+	for elm := c.lru.Front(); elm != nil; {
+		next := elm.Next()
+		if elm.Value.(*cacheEntry[K, T]).isExpired(t) {
+			evicted = c.removeElement(elm, evicted)
+			c.expirations++
+		}
+		elm = next
+	}
+	onEvict := c.onEvict
+	c.mutex.Unlock()
+	notifyEvicted(onEvict, evicted, CacheEvictionExpired)
+	return len(evicted)
+}
+
+// StartJanitor starts a goroutine which removes expired entries
+// each `interval`. The goroutine stops once the `ctx` is cancelled.
+// The method panics in case the interval is not positive.
+func (c *Cache[K, T]) StartJanitor(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		panic("StartJanitor - interval must be positive")
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.DeleteExpired()
+			}
+		}
+	}()
+}
+
+func (c *Cache[K, T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.AsMap())
+}
+
+// NewCache creates a new empty cache configured by provided options
+// (see WithCacheTTL, WithCacheMaxSize).
+func NewCache[K comparable, T any](options ...func(conf *cacheConf)) *Cache[K, T] {
+	var conf cacheConf
+	for _, opt := range options {
+		opt(&conf)
+	}
+	return &Cache[K, T]{
+		data: make(map[K]*list.Element),
+		lru:  list.New(),
+		conf: conf,
+		now:  time.Now,
+	}
+}
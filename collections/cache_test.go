@@ -0,0 +1,180 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockClock struct {
+	t time.Time
+}
+
+func (c *mockClock) now() time.Time {
+	return c.t
+}
+
+func (c *mockClock) advance(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+func newTestCache(options ...func(conf *cacheConf)) (*Cache[string, int], *mockClock) {
+	clock := &mockClock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	c := NewCache[string, int](options...)
+	c.now = clock.now
+	return c, clock
+}
+
+func TestCacheSetGet(t *testing.T) {
+	c, _ := newTestCache()
+	c.Set("foo", 10)
+	assert.Equal(t, 10, c.Get("foo"))
+	v, ok := c.GetWithTest("bar")
+	assert.Equal(t, 0, v)
+	assert.False(t, ok)
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestCacheTTL(t *testing.T) {
+	c, clock := newTestCache(WithCacheTTL(time.Minute))
+	c.Set("foo", 10)
+	c.SetWithTTL("bar", 20, 0)
+	clock.advance(59 * time.Second)
+	assert.Equal(t, 10, c.Get("foo"))
+	clock.advance(time.Second)
+	_, ok := c.GetWithTest("foo")
+	assert.False(t, ok)
+	assert.Equal(t, 20, c.Get("bar"))
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestCacheLRUEviction(t *testing.T) {
+	c, _ := newTestCache(WithCacheMaxSize(2))
+	c.Set("foo", 1)
+	c.Set("bar", 2)
+	c.Get("foo") // now "bar" is the least recently used one
+	c.Set("baz", 3)
+	assert.Equal(t, 2, c.Len())
+	_, ok := c.AsMap()["bar"]
+	assert.False(t, ok)
+	assert.Equal(t, []string{"baz", "foo"}, c.Keys())
+}
+
+func TestCacheEvictionCallback(t *testing.T) {
+	c, clock := newTestCache(WithCacheMaxSize(2), WithCacheTTL(time.Minute))
+	reasons := make(map[string]CacheEvictionReason)
+	c.OnEviction(func(k string, v int, reason CacheEvictionReason) {
+		reasons[k] = reason
+		c.Len() // accessing the cache must not deadlock
+	})
+	c.Set("foo", 1)
+	c.Set("bar", 2)
+	c.Set("baz", 3)
+	clock.advance(time.Hour)
+	assert.Equal(t, 2, c.DeleteExpired())
+	assert.Equal(
+		t,
+		map[string]CacheEvictionReason{
+			"foo": CacheEvictionCapacity,
+			"bar": CacheEvictionExpired,
+			"baz": CacheEvictionExpired,
+		},
+		reasons,
+	)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCacheDeleteDoesNotNotify(t *testing.T) {
+	c, _ := newTestCache()
+	var called bool
+	c.OnEviction(func(k string, v int, reason CacheEvictionReason) {
+		called = true
+	})
+	c.Set("foo", 1)
+	c.Delete("foo")
+	assert.Equal(t, 0, c.Len())
+	assert.False(t, called)
+}
+
+func TestCacheStats(t *testing.T) {
+	c, clock := newTestCache(WithCacheMaxSize(2))
+	c.SetWithTTL("foo", 1, time.Second)
+	c.Set("bar", 2)
+	c.Set("baz", 3)
+	c.Get("bar")
+	c.Get("foo")
+	clock.advance(time.Second)
+	c.Get("baz")
+	assert.Equal(
+		t,
+		CacheStats{Size: 2, Hits: 2, Misses: 1, Evictions: 1, Expirations: 0},
+		c.Stats(),
+	)
+}
+
+func TestCacheJanitor(t *testing.T) {
+	c := NewCache[string, int](WithCacheTTL(time.Millisecond))
+	c.Set("foo", 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.StartJanitor(ctx, 5*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return c.Len() == 0
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestCacheJanitorInvalidInterval(t *testing.T) {
+	c := NewCache[string, int]()
+	assert.Panics(t, func() { c.StartJanitor(context.Background(), 0) })
+	assert.Panics(t, func() { c.StartJanitor(context.Background(), -time.Second) })
+}
+
+func TestCacheOnEvictionSetConcurrently(t *testing.T) {
+	c := NewCache[int, int](WithCacheMaxSize(2))
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			c.Set(i, i) // evicts entries
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			c.OnEviction(func(k, v int, reason CacheEvictionReason) {})
+		}
+	}()
+	wg.Wait()
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestCacheJSON(t *testing.T) {
+	c, clock := newTestCache()
+	c.Set("foo", 1)
+	c.SetWithTTL("bar", 2, time.Second)
+	clock.advance(time.Second)
+	src, err := json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"foo":1}`, string(src))
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"sync"
+)
+
+// ConcurrentCircularList is a concurrency-safe wrapper around
+// CircularList. Iteration is performed on a snapshot of the list
+// taken at the time of the call so readers never see a list
+// modified in the middle of the iteration and writers are not
+// blocked by slow readers.
+type ConcurrentCircularList[T any] struct {
+	mutex sync.RWMutex
+	list  *CircularList[T]
+}
+
+// Append adds a new item to the end of the list. In case the
+// free capacity is depleted, then the oldest item is replaced by
+// this new one.
+func (clist *ConcurrentCircularList[T]) Append(v T) {
+	clist.mutex.Lock()
+	defer clist.mutex.Unlock()
+	clist.list.Append(v)
+}
+
+// Prepend - see CircularList.Prepend
+func (clist *ConcurrentCircularList[T]) Prepend(v T) {
+	clist.mutex.Lock()
+	defer clist.mutex.Unlock()
+	clist.list.Prepend(v)
+}
+
+// Get returns an item based on its order from the oldest (0),
+// to newest (Len() - 1).
+func (clist *ConcurrentCircularList[T]) Get(idx int) T {
+	clist.mutex.RLock()
+	defer clist.mutex.RUnlock()
+	return clist.list.Get(idx)
+}
+
+// Head returns the oldest item of the list. In case the list
+// is empty, panic() is caused.
+func (clist *ConcurrentCircularList[T]) Head() T {
+	clist.mutex.RLock()
+	defer clist.mutex.RUnlock()
+	return clist.list.Head()
+}
+
+// Last returns the most recent item of the list. In case the list
+// is empty, panic() is caused.
+func (clist *ConcurrentCircularList[T]) Last() T {
+	clist.mutex.RLock()
+	defer clist.mutex.RUnlock()
+	return clist.list.Last()
+}
+
+// DeleteWhile - see CircularList.DeleteWhile.
+// The whole operation is performed under a single write lock
+// so `fn` must not access the list.
+func (clist *ConcurrentCircularList[T]) DeleteWhile(fn func(item T) bool) {
+	clist.mutex.Lock()
+	defer clist.mutex.Unlock()
+	clist.list.DeleteWhile(fn)
+}
+
+// Len returns size of the list
+func (clist *ConcurrentCircularList[T]) Len() int {
+	clist.mutex.RLock()
+	defer clist.mutex.RUnlock()
+	return clist.list.Len()
+}
+
+// Cap returns capacity of the list
+func (clist *ConcurrentCircularList[T]) Cap() int {
+	clist.mutex.RLock()
+	defer clist.mutex.RUnlock()
+	return clist.list.Cap()
+}
+
+// Resize - see CircularList.Resize
+func (clist *ConcurrentCircularList[T]) Resize(newCap int) {
+	clist.mutex.Lock()
+	defer clist.mutex.Unlock()
+	clist.list.Resize(newCap)
+}
+
+// Snapshot returns a copy of all the items ordered from
+// the oldest to the newest one.
+func (clist *ConcurrentCircularList[T]) Snapshot() []T {
+	clist.mutex.RLock()
+	defer clist.mutex.RUnlock()
+	ans := make([]T, 0, clist.list.Len())
+	for _, v := range clist.list.IterateLogical {
+		ans = append(ans, v)
+	}
+	return ans
+}
+
+// Iterate goes through all items from oldest to newest, calling yield for each.
+// The first argument passed to yield is the logical index (0 = oldest).
+// The iteration operates on a snapshot of the list (see Snapshot) so it is safe
+// to modify the list from within the yield function.
+func (clist *ConcurrentCircularList[T]) Iterate(yield func(i int, item T) bool) {
+	for i, v := range clist.Snapshot() {
+		if !yield(i, v) {
+			return
+		}
+	}
+}
+
+func (clist *ConcurrentCircularList[T]) GobEncode() ([]byte, error) {
+	clist.mutex.RLock()
+	defer clist.mutex.RUnlock()
+	return clist.list.GobEncode()
+}
+
+func (clist *ConcurrentCircularList[T]) GobDecode(data []byte) error {
+	clist.mutex.Lock()
+	defer clist.mutex.Unlock()
+	var list CircularList[T]
+	if err := list.GobDecode(data); err != nil {
+		return err
+	}
+	clist.list = &list
+	return nil
+}
+
+// NewConcurrentCircularList is the recommended factory function
+// for ConcurrentCircularList. See NewCircularList for details.
+func NewConcurrentCircularList[T any](capacity int) *ConcurrentCircularList[T] {
+	return &ConcurrentCircularList[T]{
+		list: NewCircularList[T](capacity),
+	}
+}
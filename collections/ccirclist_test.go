@@ -0,0 +1,100 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"bytes"
+	"encoding/gob"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentCircularListBasics(t *testing.T) {
+	clist := NewConcurrentCircularList[string](3)
+	clist.Append("B")
+	clist.Append("C")
+	clist.Prepend("A")
+	clist.Append("D")
+	assert.Equal(t, 3, clist.Len())
+	assert.Equal(t, "B", clist.Head())
+	assert.Equal(t, "D", clist.Last())
+	assert.Equal(t, "C", clist.Get(1))
+	assert.Equal(t, []string{"B", "C", "D"}, clist.Snapshot())
+}
+
+func TestConcurrentCircularListDeleteWhile(t *testing.T) {
+	clist := NewConcurrentCircularList[int](5)
+	for i := 0; i < 5; i++ {
+		clist.Append(i)
+	}
+	clist.DeleteWhile(func(item int) bool { return item < 3 })
+	assert.Equal(t, []int{3, 4}, clist.Snapshot())
+}
+
+func TestConcurrentCircularListIterateAllowsWrites(t *testing.T) {
+	clist := NewConcurrentCircularList[int](5)
+	clist.Append(1)
+	clist.Append(2)
+	iTest := make([]int, 0, 2)
+	vTest := make([]int, 0, 2)
+	for i, v := range clist.Iterate {
+		clist.Append(v * 10) // must not deadlock
+		iTest = append(iTest, i)
+		vTest = append(vTest, v)
+	}
+	assert.Equal(t, []int{0, 1}, iTest)
+	assert.Equal(t, []int{1, 2}, vTest)
+	assert.Equal(t, 4, clist.Len())
+}
+
+func TestConcurrentCircularListConcurrentAccess(t *testing.T) {
+	clist := NewConcurrentCircularList[int](100)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				clist.Append(j)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				assert.LessOrEqual(t, len(clist.Snapshot()), 100)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 100, clist.Len())
+}
+
+func TestConcurrentCircularListGOBEncodeDecode(t *testing.T) {
+	clist := NewConcurrentCircularList[string](4)
+	clist.Append("a")
+	clist.Append("b")
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(clist)
+	assert.NoError(t, err)
+
+	var clist2 ConcurrentCircularList[string]
+	err = gob.NewDecoder(bytes.NewBuffer(buf.Bytes())).Decode(&clist2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, clist2.Snapshot())
+}
@@ -32,6 +32,14 @@ type CircularList[T any] struct {
 	items     []T
 	nextIdx   int
 	numUnused int
+	onEvict   func(item T)
+
+	// GrowOnFull if true then instead of replacing the oldest
+	// item (Append) or the newest one (Prepend) in a full list,
+	// the capacity of the list is doubled.
+	// Please note that growing the list invalidates internal
+	// indices obtained via AppendAndGetInternalIdx.
+	GrowOnFull bool
 }
 
 // calcIdx converts a logical index (0 = oldest item) to the physical slice index.
@@ -51,6 +59,14 @@ func (clist *CircularList[T]) Append(v T) {
 // this new one.
 // The method returns item's internal index for possible additional manipulation.
 func (clist *CircularList[T]) AppendAndGetInternalIdx(v T) int {
+	if clist.numUnused == 0 {
+		if clist.GrowOnFull {
+			clist.grow()
+
+		} else if clist.onEvict != nil && len(clist.items) > 0 {
+			clist.onEvict(clist.items[clist.nextIdx])
+		}
+	}
 	usedIdx := clist.nextIdx
 	clist.items[usedIdx] = v
 	clist.nextIdx = (usedIdx + 1) % len(clist.items)
@@ -62,20 +78,33 @@ func (clist *CircularList[T]) AppendAndGetInternalIdx(v T) int {
 
 // Prepend inserts v at the logical beginning (oldest position) of the list.
 // If the list has unused capacity, existing items are shifted right and v occupies
-// index 0. If the list is full, the newest item is silently overwritten, since there
-// is no free slot available at the beginning.
+// index 0. If the list is full, the newest item is overwritten (and passed to
+// the eviction function, if set), since there is no free slot available at
+// the beginning. With GrowOnFull enabled, the list is extended instead.
 func (clist *CircularList[T]) Prepend(v T) {
+	if clist.numUnused == 0 && clist.GrowOnFull {
+		clist.grow()
+	}
 	if clist.numUnused == 0 {
 		idx := (len(clist.items) + clist.nextIdx - 1) % len(clist.items)
+		if clist.onEvict != nil {
+			clist.onEvict(clist.items[idx])
+		}
 		clist.items[idx] = v
 
-	} else {
+	} else if clist.calcIdx(0) == 0 {
+		// items occupy [0, nextIdx) so we can shift them
 		for i := clist.nextIdx; i > 0; i-- {
 			clist.items[i] = clist.items[i-1]
 		}
 		clist.items[0] = v
 		clist.numUnused--
-		clist.nextIdx++
+		clist.nextIdx = (clist.nextIdx + 1) % len(clist.items)
+
+	} else {
+		// there is a free slot just before the oldest item
+		clist.items[clist.calcIdx(-1)] = v
+		clist.numUnused--
 	}
 }
 
@@ -148,6 +177,52 @@ func (clist *CircularList[T]) Len() int {
 	return len(clist.items) - clist.numUnused
 }
 
+// Cap returns capacity of the list
+func (clist *CircularList[T]) Cap() int {
+	return len(clist.items)
+}
+
+// OnEviction sets a function called for each item removed
+// from the list due to insufficient capacity, i.e. when Append
+// replaces the oldest item, when Prepend replaces the newest item
+// or when Resize shrinks the list.
+// Items removed via DeleteWhile are not reported.
+func (clist *CircularList[T]) OnEviction(fn func(item T)) {
+	clist.onEvict = fn
+}
+
+// Resize changes capacity of the list while preserving logical
+// order of its items. In case the new capacity is lower than
+// the current number of items, the oldest items are dropped (and
+// passed to the eviction function, if set).
+// Please note that resizing invalidates internal indices obtained
+// via AppendAndGetInternalIdx.
+func (clist *CircularList[T]) Resize(newCap int) {
+	if newCap < 1 {
+		panic(fmt.Sprintf("invalid CircularList capacity %d", newCap))
+	}
+	numDrop := max(clist.Len()-newCap, 0)
+	newItems := make([]T, newCap)
+	var n int
+	for i, v := range clist.IterateLogical {
+		if i < numDrop {
+			if clist.onEvict != nil {
+				clist.onEvict(v)
+			}
+			continue
+		}
+		newItems[n] = v
+		n++
+	}
+	clist.items = newItems
+	clist.nextIdx = n % newCap
+	clist.numUnused = newCap - n
+}
+
+func (clist *CircularList[T]) grow() {
+	clist.Resize(max(2*len(clist.items), 1))
+}
+
 // ForEach is just an alias for Iterate
 //
 // Deprecated: use Iterate instead
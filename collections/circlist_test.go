@@ -76,6 +76,30 @@ func TestPrependOnFull(t *testing.T) {
 	assert.Equal(t, "A", clist.Head())
 }
 
+func TestPrependUntilFull(t *testing.T) {
+	clist := NewCircularList[string](3)
+	clist.Append("C")
+	clist.Prepend("B")
+	clist.Prepend("A")
+	clist.Append("D")
+	assert.Equal(t, "B", clist.Get(0))
+	assert.Equal(t, "C", clist.Get(1))
+	assert.Equal(t, "D", clist.Get(2))
+}
+
+func TestPrependAfterDeleteWhile(t *testing.T) {
+	clist := NewCircularList[int](5)
+	clist.Append(1)
+	clist.Append(2)
+	clist.Append(3)
+	clist.DeleteWhile(func(item int) bool { return item < 2 })
+	clist.Prepend(0)
+	assert.Equal(t, 3, clist.Len())
+	assert.Equal(t, 0, clist.Get(0))
+	assert.Equal(t, 2, clist.Get(1))
+	assert.Equal(t, 3, clist.Get(2))
+}
+
 func TestPrependOnEmpty(t *testing.T) {
 	clist := NewCircularList[string](3)
 	clist.Prepend("X")
@@ -352,3 +376,76 @@ func TestGOBEncodeDecode(t *testing.T) {
 	assert.Equal(t, "a", clist2.Head())
 	assert.Equal(t, "d", clist2.Last())
 }
+
+func TestResizeGrow(t *testing.T) {
+	clist := NewCircularList[string](3)
+	clist.Append("A")
+	clist.Append("B")
+	clist.Append("C")
+	clist.Append("D")
+	clist.Resize(5)
+	assert.Equal(t, 5, clist.Cap())
+	assert.Equal(t, 3, clist.Len())
+	clist.Append("E")
+	clist.Append("F")
+	clist.Append("G")
+	tmp := make([]string, 0, 5)
+	for _, v := range clist.IterateLogical {
+		tmp = append(tmp, v)
+	}
+	assert.Equal(t, []string{"C", "D", "E", "F", "G"}, tmp)
+}
+
+func TestResizeShrinkDropsOldest(t *testing.T) {
+	clist := NewCircularList[int](5)
+	for i := 0; i < 7; i++ {
+		clist.Append(i)
+	}
+	evicted := make([]int, 0, 3)
+	clist.OnEviction(func(item int) {
+		evicted = append(evicted, item)
+	})
+	clist.Resize(2)
+	assert.Equal(t, 2, clist.Len())
+	assert.Equal(t, 5, clist.Head())
+	assert.Equal(t, 6, clist.Last())
+	assert.Equal(t, []int{2, 3, 4}, evicted)
+	clist.Append(7)
+	assert.Equal(t, 6, clist.Head())
+}
+
+func TestResizeInvalidCap(t *testing.T) {
+	clist := NewCircularList[int](5)
+	assert.Panics(t, func() {
+		clist.Resize(0)
+	})
+}
+
+func TestGrowOnFull(t *testing.T) {
+	clist := NewCircularList[int](2)
+	clist.GrowOnFull = true
+	for i := 0; i < 5; i++ {
+		clist.Append(i)
+	}
+	clist.Prepend(-1)
+	assert.Equal(t, 6, clist.Len())
+	assert.Equal(t, 8, clist.Cap())
+	assert.Equal(t, -1, clist.Head())
+	assert.Equal(t, 4, clist.Last())
+}
+
+func TestEvictionOnAppendAndPrepend(t *testing.T) {
+	clist := NewCircularList[string](2)
+	evicted := make([]string, 0, 2)
+	clist.OnEviction(func(item string) {
+		evicted = append(evicted, item)
+	})
+	clist.Append("A")
+	clist.Append("B")
+	assert.Equal(t, 0, len(evicted))
+	clist.Append("C")
+	clist.Prepend("X")
+	assert.Equal(t, []string{"A", "C"}, evicted)
+	clist.DeleteWhile(func(item string) bool { return true })
+	assert.Equal(t, 2, len(evicted))
+}
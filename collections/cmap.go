@@ -25,6 +25,20 @@ import (
 type ConcurrentMap[K comparable, T any] struct {
 	sync.RWMutex
 	data map[K]T
+
+	// subscribers are guarded by the map's lock
+	subscribers []*mapSubscription[K, T]
+	nextSubID   int
+
+	// nextDeliverySeq is guarded by the map's lock
+	nextDeliverySeq uint64
+
+	// deliveryMutex guards currDeliverySeq which (along with
+	// deliveryCond) keeps change notifications in the same order
+	// as the changes have been applied
+	deliveryMutex   sync.Mutex
+	deliveryCond    *sync.Cond
+	currDeliverySeq uint64
 }
 
 func (cm *ConcurrentMap[K, T]) Get(k K) T {
@@ -49,14 +63,118 @@ func (cm *ConcurrentMap[K, T]) HasKey(k K) bool {
 
 func (cm *ConcurrentMap[K, T]) Set(k K, v T) {
 	cm.Lock()
-	defer cm.Unlock()
+	old, existed := cm.data[k]
 	cm.data[k] = v
+	cm.unlockAndPublish(
+		MapChange[K, T]{Type: MapChangeSet, Key: k, Value: v, OldValue: old, Existed: existed})
 }
 
 func (cm *ConcurrentMap[K, T]) Delete(k K) {
 	cm.Lock()
-	defer cm.Unlock()
+	old, existed := cm.data[k]
+	if !existed {
+		cm.Unlock()
+		return
+	}
 	delete(cm.data, k)
+	cm.unlockAndPublish(
+		MapChange[K, T]{Type: MapChangeDelete, Key: k, OldValue: old, Existed: true})
+}
+
+// GetOrSet returns an existing value for the key `k`. If there is
+// no such key, `v` is stored and returned. The second returned value
+// is true if the value was already present in the map.
+// The whole operation is performed under a single write lock.
+func (cm *ConcurrentMap[K, T]) GetOrSet(k K, v T) (T, bool) {
+	cm.Lock()
+	if curr, ok := cm.data[k]; ok {
+		cm.Unlock()
+		return curr, true
+	}
+	cm.data[k] = v
+	cm.unlockAndPublish(MapChange[K, T]{Type: MapChangeSet, Key: k, Value: v})
+	return v, false
+}
+
+// GetOrCompute returns an existing value for the key `k`. If there
+// is no such key, `fn` is called to create the value which is then
+// stored and returned. The second returned value is true if the value
+// was already present in the map.
+// The whole operation is performed under a single write lock so `fn`
+// is called at most once for a missing key. It must not access the map.
+func (cm *ConcurrentMap[K, T]) GetOrCompute(k K, fn func() T) (T, bool) {
+	cm.Lock()
+	if curr, ok := cm.data[k]; ok {
+		cm.Unlock()
+		return curr, true
+	}
+	v := fn()
+	cm.data[k] = v
+	cm.unlockAndPublish(MapChange[K, T]{Type: MapChangeSet, Key: k, Value: v})
+	return v, false
+}
+
+// Compute calls `fn` with the current value for the key `k` (along with
+// information whether the key exists) and stores the value returned by `fn`.
+// In case `fn` returns false as its second value, the key is deleted
+// from the map instead. The method returns the new value and whether
+// it is stored in the map.
+// The whole operation is performed under a single write lock. The `fn`
+// function must not access the map.
+func (cm *ConcurrentMap[K, T]) Compute(k K, fn func(old T, exists bool) (T, bool)) (T, bool) {
+	cm.Lock()
+	old, exists := cm.data[k]
+	v, keep := fn(old, exists)
+	if !keep {
+		if !exists {
+			cm.Unlock()
+
+		} else {
+			delete(cm.data, k)
+			cm.unlockAndPublish(
+				MapChange[K, T]{Type: MapChangeDelete, Key: k, OldValue: old, Existed: true})
+		}
+		var zeroVal T
+		return zeroVal, false
+	}
+	cm.data[k] = v
+	cm.unlockAndPublish(
+		MapChange[K, T]{Type: MapChangeSet, Key: k, Value: v, OldValue: old, Existed: exists})
+	return v, true
+}
+
+// CompareAndSwap replaces the value for the key `k` with `newVal`
+// in case the current value is equal to `old`. It returns true if
+// the value has been swapped.
+// Similarly to sync.Map, the stored values must be of a comparable
+// type, otherwise the method panics.
+func (cm *ConcurrentMap[K, T]) CompareAndSwap(k K, old, newVal T) bool {
+	cm.Lock()
+	curr, ok := cm.data[k]
+	if !ok || any(curr) != any(old) {
+		cm.Unlock()
+		return false
+	}
+	cm.data[k] = newVal
+	cm.unlockAndPublish(
+		MapChange[K, T]{Type: MapChangeSet, Key: k, Value: newVal, OldValue: curr, Existed: true})
+	return true
+}
+
+// LoadAndDelete deletes the value for the key `k` and returns
+// the previous value (if any). The second returned value reports
+// whether the key was present.
+func (cm *ConcurrentMap[K, T]) LoadAndDelete(k K) (T, bool) {
+	cm.Lock()
+	v, ok := cm.data[k]
+	if !ok {
+		cm.Unlock()
+		return v, false
+	}
+	delete(cm.data, k)
+	cm.unlockAndPublish(
+		MapChange[K, T]{Type: MapChangeDelete, Key: k, OldValue: v, Existed: true})
+	return v, true
 }
 
 // ForEach iterates through all the items. Due to concurrent
@@ -147,10 +265,21 @@ func (m *ConcurrentMap[K, V]) Count(pred func(K, V) bool) int {
 
 func (cm *ConcurrentMap[K, T]) Update(fn func(k K, v T) T) {
 	cm.Lock()
-	defer cm.Unlock()
+	var changes []MapChange[K, T]
+	if len(cm.subscribers) > 0 {
+		changes = make([]MapChange[K, T], 0, len(cm.data))
+	}
 	for k, v := range cm.data {
-		cm.data[k] = fn(k, v)
+		newVal := fn(k, v)
+		cm.data[k] = newVal
+		if changes != nil {
+			changes = append(
+				changes,
+				MapChange[K, T]{Type: MapChangeUpdate, Key: k, Value: newVal, OldValue: v, Existed: true},
+			)
+		}
 	}
+	cm.unlockAndPublish(changes...)
 }
 
 func (cm *ConcurrentMap[K, T]) Keys() []K {
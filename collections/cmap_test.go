@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -169,6 +170,77 @@ func TestConcurrentMapCount(t *testing.T) {
 	assert.Equal(t, 0, v.Count(func(k string, v int) bool { return v > 10 }))
 }
 
+func TestConcurrentMapGetOrSet(t *testing.T) {
+	c := NewConcurrentMap[string, int]()
+	v, loaded := c.GetOrSet("foo", 10)
+	assert.Equal(t, 10, v)
+	assert.False(t, loaded)
+	v, loaded = c.GetOrSet("foo", 20)
+	assert.Equal(t, 10, v)
+	assert.True(t, loaded)
+}
+
+func TestConcurrentMapGetOrCompute(t *testing.T) {
+	c := NewConcurrentMap[string, int]()
+	var numCalls int
+	fn := func() int {
+		numCalls++
+		return 42
+	}
+	v, loaded := c.GetOrCompute("foo", fn)
+	assert.Equal(t, 42, v)
+	assert.False(t, loaded)
+	v, loaded = c.GetOrCompute("foo", fn)
+	assert.Equal(t, 42, v)
+	assert.True(t, loaded)
+	assert.Equal(t, 1, numCalls)
+}
+
+func TestConcurrentMapCompute(t *testing.T) {
+	c := NewConcurrentMap[string, int]()
+	incr := func(old int, exists bool) (int, bool) {
+		return old + 1, true
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Compute("foo", incr)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 50, c.Get("foo"))
+
+	v, ok := c.Compute("foo", func(old int, exists bool) (int, bool) {
+		return 0, false
+	})
+	assert.Equal(t, 0, v)
+	assert.False(t, ok)
+	assert.False(t, c.HasKey("foo"))
+}
+
+func TestConcurrentMapCompareAndSwap(t *testing.T) {
+	c := NewConcurrentMapFrom(map[string]int{"foo": 1})
+	assert.False(t, c.CompareAndSwap("foo", 2, 3))
+	assert.Equal(t, 1, c.Get("foo"))
+	assert.True(t, c.CompareAndSwap("foo", 1, 3))
+	assert.Equal(t, 3, c.Get("foo"))
+	assert.False(t, c.CompareAndSwap("bar", 0, 3))
+	assert.False(t, c.HasKey("bar"))
+}
+
+func TestConcurrentMapLoadAndDelete(t *testing.T) {
+	c := NewConcurrentMapFrom(map[string]int{"foo": 1})
+	v, ok := c.LoadAndDelete("foo")
+	assert.Equal(t, 1, v)
+	assert.True(t, ok)
+	assert.Equal(t, 0, c.Len())
+	v, ok = c.LoadAndDelete("foo")
+	assert.Equal(t, 0, v)
+	assert.False(t, ok)
+}
+
 func TestForeach(t *testing.T) {
 	v := NewConcurrentMapFrom(map[string]int{
 		"foo": 1,
@@ -0,0 +1,192 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type SnapshotFormat string
+
+const (
+	SnapshotFormatGob  SnapshotFormat = "gob"
+	SnapshotFormatJSON SnapshotFormat = "json"
+)
+
+func (cm *ConcurrentMap[K, T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := gob.NewEncoder(&buf)
+	if err := encoder.Encode(cm.AsMap()); err != nil {
+		return []byte{}, fmt.Errorf("failed to GOB encode ConcurrentMap: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (cm *ConcurrentMap[K, T]) GobDecode(data []byte) error {
+	data2 := make(map[K]T)
+	decoder := gob.NewDecoder(bytes.NewBuffer(data))
+	if err := decoder.Decode(&data2); err != nil {
+		return fmt.Errorf("failed to GOB decode ConcurrentMap: %w", err)
+	}
+	cm.Lock()
+	cm.data = data2
+	cm.Unlock()
+	return nil
+}
+
+func (cm *ConcurrentMap[K, T]) UnmarshalJSON(data []byte) error {
+	data2 := make(map[K]T)
+	if err := json.Unmarshal(data, &data2); err != nil {
+		return err
+	}
+	cm.Lock()
+	cm.data = data2
+	cm.Unlock()
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory
+// as the target one and then renames it to the target path. This way,
+// readers never see a partially written file.
+// The permissions of an existing target file are preserved, a new file
+// is created with permissions 0644.
+func writeFileAtomic(path string, data []byte) error {
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// SaveToFile stores a snapshot of the map to a file in the specified
+// format. The file is written atomically - the data are first written
+// to a temporary file which is then renamed to `path`. Permissions
+// of an existing file are kept, new files are created with 0644.
+func (cm *ConcurrentMap[K, T]) SaveToFile(path string, format SnapshotFormat) error {
+	var data []byte
+	var err error
+	switch format {
+	case SnapshotFormatGob:
+		data, err = cm.GobEncode()
+	case SnapshotFormatJSON:
+		data, err = cm.MarshalJSON()
+	default:
+		err = fmt.Errorf("unknown snapshot format %s", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save ConcurrentMap to %s: %w", path, err)
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to save ConcurrentMap to %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadFromFile replaces the map content by data loaded from a file
+// previously created via SaveToFile.
+func (cm *ConcurrentMap[K, T]) LoadFromFile(path string, format SnapshotFormat) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to load ConcurrentMap from %s: %w", path, err)
+	}
+	switch format {
+	case SnapshotFormatGob:
+		err = cm.GobDecode(data)
+	case SnapshotFormatJSON:
+		err = cm.UnmarshalJSON(data)
+	default:
+		err = fmt.Errorf("unknown snapshot format %s", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load ConcurrentMap from %s: %w", path, err)
+	}
+	return nil
+}
+
+// RunAutosave periodically stores the map to a file (see SaveToFile).
+// Once the `ctx` is cancelled, the map is saved for the last time
+// and the function returns. Errors are logged.
+// Typically, this function should run in its own goroutine.
+func (cm *ConcurrentMap[K, T]) RunAutosave(
+	ctx context.Context,
+	path string,
+	format SnapshotFormat,
+	interval time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := cm.SaveToFile(path, format); err != nil {
+				log.Error().Err(err).Msg("failed to perform final autosave of ConcurrentMap")
+			}
+			return
+		case <-ticker.C:
+			if err := cm.SaveToFile(path, format); err != nil {
+				log.Error().Err(err).Msg("failed to autosave ConcurrentMap")
+			}
+		}
+	}
+}
+
+// NewConcurrentMapFromFile creates a new ConcurrentMap with data
+// loaded from a file previously created via SaveToFile.
+func NewConcurrentMapFromFile[K comparable, T any](path string, format SnapshotFormat) (*ConcurrentMap[K, T], error) {
+	ans := NewConcurrentMap[K, T]()
+	if err := ans.LoadFromFile(path, format); err != nil {
+		return nil, err
+	}
+	return ans, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentMapGOBEncodeDecode(t *testing.T) {
+	c := NewConcurrentMapFrom(map[string]int{"foo": 1, "bar": 2})
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(c)
+	assert.NoError(t, err)
+
+	var c2 ConcurrentMap[string, int]
+	err = gob.NewDecoder(bytes.NewBuffer(buf.Bytes())).Decode(&c2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"foo": 1, "bar": 2}, c2.AsMap())
+}
+
+func TestConcurrentMapUnmarshalJSON(t *testing.T) {
+	var c ConcurrentMap[string, int]
+	err := json.Unmarshal([]byte(`{"foo": 1, "bar": 2}`), &c)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"foo": 1, "bar": 2}, c.AsMap())
+}
+
+func TestConcurrentMapSaveLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	c := NewConcurrentMapFrom(map[string]int{"foo": 1, "bar": 2})
+	for _, format := range []SnapshotFormat{SnapshotFormatGob, SnapshotFormatJSON} {
+		path := filepath.Join(dir, "data."+string(format))
+		assert.NoError(t, c.SaveToFile(path, format))
+		c2, err := NewConcurrentMapFromFile[string, int](path, format)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"foo": 1, "bar": 2}, c2.AsMap())
+	}
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(entries)) // no temporary files left
+}
+
+func TestConcurrentMapSaveFilePermissions(t *testing.T) {
+	dir := t.TempDir()
+	c := NewConcurrentMapFrom(map[string]int{"foo": 1})
+	path := filepath.Join(dir, "data.json")
+	assert.NoError(t, c.SaveToFile(path, SnapshotFormatJSON))
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+
+	assert.NoError(t, os.Chmod(path, 0640))
+	assert.NoError(t, c.SaveToFile(path, SnapshotFormatJSON))
+	info, err = os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+}
+
+func TestConcurrentMapLoadFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	c := NewConcurrentMapFrom(map[string]int{"foo": 1})
+	_, err := NewConcurrentMapFromFile[string, int](filepath.Join(dir, "missing"), SnapshotFormatGob)
+	assert.Error(t, err)
+	assert.Error(t, c.SaveToFile(filepath.Join(dir, "data"), SnapshotFormat("xml")))
+	path := filepath.Join(dir, "data.json")
+	assert.NoError(t, c.SaveToFile(path, SnapshotFormatJSON))
+	assert.Error(t, c.LoadFromFile(path, SnapshotFormatGob))
+	assert.Equal(t, 1, c.Get("foo"))
+}
+
+func TestConcurrentMapRunAutosave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.gob")
+	c := NewConcurrentMapFrom(map[string]int{"foo": 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.RunAutosave(ctx, path, SnapshotFormatGob, time.Hour)
+		close(done)
+	}()
+	c.Set("bar", 2)
+	cancel()
+	<-done
+	c2, err := NewConcurrentMapFromFile[string, int](path, SnapshotFormatGob)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"foo": 1, "bar": 2}, c2.AsMap())
+}
@@ -0,0 +1,326 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"slices"
+	"sync"
+)
+
+type MapChangeType string
+
+const (
+	// MapChangeSet is produced by Set and by the atomic
+	// operations which store a value (GetOrSet, Compute,...)
+	MapChangeSet MapChangeType = "set"
+
+	// MapChangeDelete is produced by Delete and by the atomic
+	// operations which remove a value (LoadAndDelete, Compute)
+	MapChangeDelete MapChangeType = "delete"
+
+	// MapChangeUpdate is produced for each key modified by Update
+	MapChangeUpdate MapChangeType = "update"
+)
+
+// MapChange describes a single modification of a ConcurrentMap
+type MapChange[K comparable, T any] struct {
+	Type MapChangeType
+	Key  K
+
+	// Value is the new value (zero value for MapChangeDelete)
+	Value T
+
+	// OldValue is the value replaced or removed by the change.
+	// It is valid only if Existed is true.
+	OldValue T
+
+	// Existed specifies whether the key had been present
+	// in the map before the change
+	Existed bool
+}
+
+// SubscriptionPolicy specifies how change events are delivered
+// to a subscriber's channel in case the subscriber cannot keep up.
+type SubscriptionPolicy int
+
+const (
+	// SubscriptionDrop discards events which do not fit
+	// into the channel's buffer
+	SubscriptionDrop SubscriptionPolicy = iota
+
+	// SubscriptionBlock makes the modifying operation wait until
+	// the event is received. The map itself is not locked while waiting
+	// so readers are not affected but as the events are delivered
+	// in order, a slow subscriber slows down all writers.
+	// The consumer of the channel must not modify the map as its own
+	// change would wait for the delivery it is supposed to finish
+	// (i.e. a deadlock).
+	SubscriptionBlock
+
+	// SubscriptionBuffer stores events in an unbounded internal queue
+	// so neither writers are blocked nor events are lost (at the cost
+	// of possibly unlimited memory usage).
+	SubscriptionBuffer
+)
+
+type mapSubscription[K comparable, T any] struct {
+	id     int
+	fn     func(change MapChange[K, T])
+	ch     chan MapChange[K, T]
+	policy SubscriptionPolicy
+
+	// done is closed on unsubscribe
+	done chan struct{}
+
+	// stateMutex guards `pending` (number of started deliveries
+	// which may still access the subscription) and `removed`
+	stateMutex sync.Mutex
+	pending    int
+	removed    bool
+
+	// following fields are used only by SubscriptionBuffer
+	queueMutex sync.Mutex
+	queue      []MapChange[K, T]
+	queueReady chan struct{}
+	pumpDone   chan struct{}
+}
+
+func (sub *mapSubscription[K, T]) isDone() bool {
+	select {
+	case <-sub.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// acquire registers a starting delivery. It must be called with
+// the map's lock held (i.e. while the subscription is registered).
+func (sub *mapSubscription[K, T]) acquire() {
+	sub.stateMutex.Lock()
+	sub.pending++
+	sub.stateMutex.Unlock()
+}
+
+// release unregisters a finished delivery. In case the subscription
+// has been removed in the meantime, the last delivery closes the channel.
+func (sub *mapSubscription[K, T]) release() {
+	sub.stateMutex.Lock()
+	defer sub.stateMutex.Unlock()
+	sub.pending--
+	if sub.pending == 0 && sub.removed {
+		sub.closeChannel()
+	}
+}
+
+func (sub *mapSubscription[K, T]) closeChannel() {
+	if sub.ch != nil {
+		close(sub.ch)
+	}
+}
+
+func (sub *mapSubscription[K, T]) deliver(change MapChange[K, T]) {
+	if sub.isDone() {
+		return
+	}
+	if sub.fn != nil {
+		sub.fn(change)
+		return
+	}
+	switch sub.policy {
+	case SubscriptionDrop:
+		select {
+		case sub.ch <- change:
+		default:
+		}
+	case SubscriptionBlock:
+		select {
+		case sub.ch <- change:
+		case <-sub.done:
+		}
+	case SubscriptionBuffer:
+		sub.queueMutex.Lock()
+		sub.queue = append(sub.queue, change)
+		sub.queueMutex.Unlock()
+		select {
+		case sub.queueReady <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// runPump forwards queued events to the subscriber's channel
+// (SubscriptionBuffer only)
+func (sub *mapSubscription[K, T]) runPump() {
+	defer close(sub.pumpDone)
+	for {
+		sub.queueMutex.Lock()
+		items := sub.queue
+		sub.queue = nil
+		sub.queueMutex.Unlock()
+		if len(items) == 0 {
+			select {
+			case <-sub.queueReady:
+				continue
+			case <-sub.done:
+				return
+			}
+		}
+		for _, item := range items {
+			select {
+			case sub.ch <- item:
+			case <-sub.done:
+				return
+			}
+		}
+	}
+}
+
+// unlockAndPublish releases the map's write lock and delivers
+// provided changes to all the subscribers. The method must be
+// called with the write lock held.
+// To keep events from concurrent writers in order, each publishing
+// gets a sequence number (while the map is still locked) and waits
+// for its turn only after the map's lock is released. This way
+// neither readers nor subscribers accessing the map are blocked.
+func (cm *ConcurrentMap[K, T]) unlockAndPublish(changes ...MapChange[K, T]) {
+	if len(cm.subscribers) == 0 || len(changes) == 0 {
+		cm.Unlock()
+		return
+	}
+	subscribers := slices.Clone(cm.subscribers)
+	for _, sub := range subscribers {
+		sub.acquire()
+	}
+	seq := cm.nextDeliverySeq
+	cm.nextDeliverySeq++
+	cm.Unlock()
+
+	// the releases must run even if a subscriber function panics,
+	// otherwise the respective channels would never be closed
+	defer func() {
+		for _, sub := range subscribers {
+			sub.release()
+		}
+	}()
+	cm.waitForDeliveryTurn(seq)
+	defer cm.finishDeliveryTurn()
+	for _, change := range changes {
+		for _, sub := range subscribers {
+			sub.deliver(change)
+		}
+	}
+}
+
+func (cm *ConcurrentMap[K, T]) waitForDeliveryTurn(seq uint64) {
+	cm.deliveryMutex.Lock()
+	defer cm.deliveryMutex.Unlock()
+	if cm.deliveryCond == nil {
+		cm.deliveryCond = sync.NewCond(&cm.deliveryMutex)
+	}
+	for cm.currDeliverySeq != seq {
+		cm.deliveryCond.Wait()
+	}
+}
+
+func (cm *ConcurrentMap[K, T]) finishDeliveryTurn() {
+	cm.deliveryMutex.Lock()
+	defer cm.deliveryMutex.Unlock()
+	cm.currDeliverySeq++
+	if cm.deliveryCond != nil {
+		cm.deliveryCond.Broadcast()
+	}
+}
+
+func (cm *ConcurrentMap[K, T]) addSubscription(sub *mapSubscription[K, T]) {
+	cm.Lock()
+	defer cm.Unlock()
+	sub.id = cm.nextSubID
+	cm.nextSubID++
+	cm.subscribers = append(cm.subscribers, sub)
+}
+
+// removeSubscription unregisters the subscription. The `done` channel
+// is closed first (without any lock) so possibly blocked deliveries
+// can finish. The subscriber's channel is closed either immediately
+// or by the last running delivery which still may access it.
+func (cm *ConcurrentMap[K, T]) removeSubscription(sub *mapSubscription[K, T]) {
+	close(sub.done)
+	cm.Lock()
+	cm.subscribers = slices.DeleteFunc(cm.subscribers, func(item *mapSubscription[K, T]) bool {
+		return item.id == sub.id
+	})
+	cm.Unlock()
+	if sub.pumpDone != nil {
+		<-sub.pumpDone
+	}
+	sub.stateMutex.Lock()
+	defer sub.stateMutex.Unlock()
+	sub.removed = true
+	if sub.pending == 0 {
+		sub.closeChannel()
+	}
+}
+
+// Subscribe registers a new subscriber of the map's changes (caused
+// by Set, Delete, Update and the atomic operations). Bulk replacements
+// of the map content (GobDecode, UnmarshalJSON, LoadFromFile) are not
+// reported.
+// The events are delivered to the returned channel with a buffer of size
+// `bufferSize` according to the `policy`. The returned function cancels
+// the subscription and closes the channel (in case some events are just
+// being delivered, the channel is closed once the delivery is finished).
+// It is safe to call it multiple times.
+// With SubscriptionBlock, the consumer of the channel must not modify
+// the map (the modification would wait for the blocked delivery forever).
+func (cm *ConcurrentMap[K, T]) Subscribe(
+	bufferSize int,
+	policy SubscriptionPolicy,
+) (<-chan MapChange[K, T], func()) {
+	sub := &mapSubscription[K, T]{
+		ch:     make(chan MapChange[K, T], bufferSize),
+		policy: policy,
+		done:   make(chan struct{}),
+	}
+	if policy == SubscriptionBuffer {
+		sub.queueReady = make(chan struct{}, 1)
+		sub.pumpDone = make(chan struct{})
+		go sub.runPump()
+	}
+	cm.addSubscription(sub)
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() { cm.removeSubscription(sub) })
+	}
+}
+
+// SubscribeFunc registers a function called synchronously for each
+// change of the map (see Subscribe for the list of reported operations).
+// The function may read the map but it must not modify it. The events
+// are delivered in the order of the respective changes.
+// The returned function cancels the subscription.
+func (cm *ConcurrentMap[K, T]) SubscribeFunc(fn func(change MapChange[K, T])) func() {
+	sub := &mapSubscription[K, T]{
+		fn:   fn,
+		done: make(chan struct{}),
+	}
+	cm.addSubscription(sub)
+	var once sync.Once
+	return func() {
+		once.Do(func() { cm.removeSubscription(sub) })
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentMapSubscribeFunc(t *testing.T) {
+	c := NewConcurrentMap[string, int]()
+	changes := make([]MapChange[string, int], 0, 5)
+	unsubscribe := c.SubscribeFunc(func(change MapChange[string, int]) {
+		assert.True(t, c.Len() >= 0) // reading the map must not deadlock
+		changes = append(changes, change)
+	})
+	c.Set("foo", 1)
+	c.Set("foo", 2)
+	c.Delete("foo")
+	c.Delete("bar") // no change, no event
+	c.Set("bar", 10)
+	c.Update(func(k string, v int) int { return v + 1 })
+	unsubscribe()
+	c.Set("baz", 100)
+	assert.Equal(
+		t,
+		[]MapChange[string, int]{
+			{Type: MapChangeSet, Key: "foo", Value: 1},
+			{Type: MapChangeSet, Key: "foo", Value: 2, OldValue: 1, Existed: true},
+			{Type: MapChangeDelete, Key: "foo", OldValue: 2, Existed: true},
+			{Type: MapChangeSet, Key: "bar", Value: 10},
+			{Type: MapChangeUpdate, Key: "bar", Value: 11, OldValue: 10, Existed: true},
+		},
+		changes,
+	)
+}
+
+func TestConcurrentMapSubscribeAtomicOps(t *testing.T) {
+	c := NewConcurrentMap[string, int]()
+	types := make([]MapChangeType, 0, 5)
+	c.SubscribeFunc(func(change MapChange[string, int]) {
+		types = append(types, change.Type)
+	})
+	c.GetOrSet("foo", 1)
+	c.GetOrSet("foo", 2) // no change
+	c.CompareAndSwap("foo", 1, 3)
+	c.Compute("foo", func(old int, exists bool) (int, bool) { return 0, false })
+	c.GetOrCompute("bar", func() int { return 1 })
+	c.LoadAndDelete("bar")
+	assert.Equal(
+		t,
+		[]MapChangeType{
+			MapChangeSet, MapChangeSet, MapChangeDelete, MapChangeSet, MapChangeDelete,
+		},
+		types,
+	)
+}
+
+func TestConcurrentMapSubscribeDrop(t *testing.T) {
+	c := NewConcurrentMap[string, int]()
+	ch, unsubscribe := c.Subscribe(2, SubscriptionDrop)
+	c.Set("foo", 1)
+	c.Set("bar", 2)
+	c.Set("baz", 3) // dropped
+	unsubscribe()
+	keys := make([]string, 0, 3)
+	for change := range ch {
+		keys = append(keys, change.Key)
+	}
+	assert.Equal(t, []string{"foo", "bar"}, keys)
+}
+
+func TestConcurrentMapSubscribeBlock(t *testing.T) {
+	c := NewConcurrentMap[string, int]()
+	ch, unsubscribe := c.Subscribe(0, SubscriptionBlock)
+	defer unsubscribe()
+	go func() {
+		for i := 0; i < 10; i++ {
+			c.Set("foo", i)
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		change := <-ch
+		assert.Equal(t, i, change.Value)
+	}
+}
+
+func TestConcurrentMapUnsubscribeBlocked(t *testing.T) {
+	c := NewConcurrentMap[string, int]()
+	_, unsubscribe := c.Subscribe(0, SubscriptionBlock)
+	done := make(chan struct{})
+	go func() {
+		c.Set("foo", 1) // nobody reads the channel
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	unsubscribe()
+	unsubscribe()
+	<-done
+	assert.Equal(t, 1, c.Get("foo"))
+}
+
+func TestConcurrentMapSubscribeBuffer(t *testing.T) {
+	c := NewConcurrentMap[int, int]()
+	ch, unsubscribe := c.Subscribe(0, SubscriptionBuffer)
+	defer unsubscribe()
+	for i := 0; i < 100; i++ {
+		c.Set(i, i) // must not block
+	}
+	for i := 0; i < 100; i++ {
+		change := <-ch
+		assert.Equal(t, i, change.Key)
+	}
+}
+
+// waitOrFail fails the test in case `done` is not closed in time
+// (which means a deadlock in our case)
+func waitOrFail(t *testing.T, done <-chan struct{}, msg string) {
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal(msg)
+	}
+}
+
+func TestConcurrentMapSubscribeFuncReadsDuringConcurrentWrites(t *testing.T) {
+	c := NewConcurrentMap[int, int]()
+	c.SubscribeFunc(func(change MapChange[int, int]) {
+		time.Sleep(time.Millisecond) // let the other writer queue up
+		c.Len()
+	})
+	done := make(chan struct{})
+	go func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					c.Set(i, j)
+				}
+			}(i)
+		}
+		wg.Wait()
+		close(done)
+	}()
+	waitOrFail(t, done, "writers with a reading subscriber deadlocked")
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestConcurrentMapUnsubscribeBlockedConcurrentWriters(t *testing.T) {
+	c := NewConcurrentMap[string, int]()
+	ch, unsubscribe := c.Subscribe(0, SubscriptionBlock)
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.Set(fmt.Sprintf("k%d", i), i) // nobody reads the channel
+		}(i)
+	}
+	time.Sleep(10 * time.Millisecond)
+	done := make(chan struct{})
+	go func() {
+		unsubscribe()
+		wg.Wait()
+		close(done)
+	}()
+	waitOrFail(t, done, "unsubscribe with blocked writers deadlocked")
+	for range ch {
+	} // the channel must be closed eventually
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestConcurrentMapReadersNotBlockedBySlowSubscriber(t *testing.T) {
+	c := NewConcurrentMap[string, int]()
+	_, unsubscribe := c.Subscribe(0, SubscriptionBlock)
+	defer unsubscribe()
+	go c.Set("foo", 1) // nobody reads the channel
+	time.Sleep(10 * time.Millisecond)
+	done := make(chan struct{})
+	go func() {
+		c.Get("foo")
+		c.Set("bar", 2) // waits for delivery but must not lock the map
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	readDone := make(chan struct{})
+	go func() {
+		assert.Equal(t, 1, c.Get("foo"))
+		assert.Equal(t, 2, c.Get("bar"))
+		close(readDone)
+	}()
+	waitOrFail(t, readDone, "readers blocked by a slow subscriber")
+}
+
+func TestConcurrentMapSubscribeKeepsOrder(t *testing.T) {
+	c := NewConcurrentMap[string, int]()
+	var last int
+	c.SubscribeFunc(func(change MapChange[string, int]) {
+		last = change.Value
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				c.Set("foo", i*1000+j)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, c.Get("foo"), last)
+}
+
+func TestConcurrentMapSubscribeFuncPanicReleasesSubscriptions(t *testing.T) {
+	c := NewConcurrentMap[string, int]()
+	ch, unsubscribe := c.Subscribe(10, SubscriptionDrop)
+	c.SubscribeFunc(func(change MapChange[string, int]) {
+		if change.Key == "boom" {
+			panic("subscriber failed")
+		}
+	})
+	assert.Panics(t, func() { c.Set("boom", 1) })
+	done := make(chan struct{})
+	go func() {
+		c.Set("foo", 2) // a next writer must get its delivery turn
+		unsubscribe()
+		for range ch {
+		} // the channel must be closed
+		close(done)
+	}()
+	waitOrFail(t, done, "a panicking subscriber blocked the map")
+}
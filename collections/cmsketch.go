@@ -0,0 +1,169 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"math"
+)
+
+// CountMinSketch is a probabilistic frequency table. It never
+// underestimates frequencies and with probability at least 1 - delta,
+// the overestimation is at most epsilon * (total count of all values).
+// The sketch is not concurrency-safe.
+type CountMinSketch struct {
+	counts []uint64
+	width  uint64
+	depth  uint64
+	total  uint64
+}
+
+func (cms *CountMinSketch) cellIdx(h1, h2 uint64, row uint64) uint64 {
+	return row*cms.width + (h1+row*h2)%cms.width
+}
+
+// Add increases the frequency of a value by `count`
+func (cms *CountMinSketch) Add(data []byte, count uint64) {
+	h1, h2 := doubleHash(data)
+	for row := uint64(0); row < cms.depth; row++ {
+		cms.counts[cms.cellIdx(h1, h2, row)] += count
+	}
+	cms.total += count
+}
+
+// AddString increases the frequency of a string value by `count`
+func (cms *CountMinSketch) AddString(s string, count uint64) {
+	cms.Add([]byte(s), count)
+}
+
+// Estimate returns an estimated frequency of a value
+func (cms *CountMinSketch) Estimate(data []byte) uint64 {
+	h1, h2 := doubleHash(data)
+	ans := uint64(math.MaxUint64)
+	for row := uint64(0); row < cms.depth; row++ {
+		ans = min(ans, cms.counts[cms.cellIdx(h1, h2, row)])
+	}
+	return ans
+}
+
+// EstimateString returns an estimated frequency of a string value
+func (cms *CountMinSketch) EstimateString(s string) uint64 {
+	return cms.Estimate([]byte(s))
+}
+
+// Total returns the sum of all the added counts
+func (cms *CountMinSketch) Total() uint64 {
+	return cms.total
+}
+
+// Width returns number of counters per row
+func (cms *CountMinSketch) Width() uint64 {
+	return cms.width
+}
+
+// Depth returns number of rows (i.e. hash functions)
+func (cms *CountMinSketch) Depth() uint64 {
+	return cms.depth
+}
+
+// Merge adds all the counts from the `other` sketch. Both the sketches
+// must have the same dimensions (i.e. they must have been created
+// with the same epsilon and delta).
+func (cms *CountMinSketch) Merge(other *CountMinSketch) error {
+	if cms.width != other.width || cms.depth != other.depth {
+		return fmt.Errorf(
+			"failed to merge CountMinSketch: incompatible dimensions (%dx%d vs. %dx%d)",
+			cms.depth, cms.width, other.depth, other.width,
+		)
+	}
+	for i, v := range other.counts {
+		cms.counts[i] += v
+	}
+	cms.total += other.total
+	return nil
+}
+
+// Reset sets all the counts to zero
+func (cms *CountMinSketch) Reset() {
+	clear(cms.counts)
+	cms.total = 0
+}
+
+func (cms *CountMinSketch) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := gob.NewEncoder(&buf)
+	if err := encoder.Encode(cms.counts); err != nil {
+		return []byte{}, fmt.Errorf("failed to GOB encode CountMinSketch: %w", err)
+	}
+	if err := encoder.Encode(cms.width); err != nil {
+		return []byte{}, fmt.Errorf("failed to GOB encode CountMinSketch: %w", err)
+	}
+	if err := encoder.Encode(cms.depth); err != nil {
+		return []byte{}, fmt.Errorf("failed to GOB encode CountMinSketch: %w", err)
+	}
+	if err := encoder.Encode(cms.total); err != nil {
+		return []byte{}, fmt.Errorf("failed to GOB encode CountMinSketch: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (cms *CountMinSketch) GobDecode(data []byte) error {
+	buf := bytes.NewBuffer(data)
+	decoder := gob.NewDecoder(buf)
+	if err := decoder.Decode(&cms.counts); err != nil {
+		return fmt.Errorf("failed to GOB decode CountMinSketch: %w", err)
+	}
+	if err := decoder.Decode(&cms.width); err != nil {
+		return fmt.Errorf("failed to GOB decode CountMinSketch: %w", err)
+	}
+	if err := decoder.Decode(&cms.depth); err != nil {
+		return fmt.Errorf("failed to GOB decode CountMinSketch: %w", err)
+	}
+	if err := decoder.Decode(&cms.total); err != nil {
+		return fmt.Errorf("failed to GOB decode CountMinSketch: %w", err)
+	}
+	if cms.width == 0 || cms.depth == 0 || cms.width > math.MaxUint64/cms.depth {
+		return fmt.Errorf("failed to GOB decode CountMinSketch: invalid sketch dimensions")
+	}
+	if uint64(len(cms.counts)) != cms.width*cms.depth {
+		return fmt.Errorf("failed to GOB decode CountMinSketch: inconsistent data size")
+	}
+	return nil
+}
+
+// NewCountMinSketch creates a new CountMinSketch where the estimation
+// error is at most `epsilon` * (total count) with probability 1 - `delta`.
+// E.g. epsilon = 0.001 and delta = 0.01 creates a sketch of 5 x 2719
+// counters. The function panics if any of the arguments is not from
+// the (0, 1) interval.
+func NewCountMinSketch(epsilon, delta float64) *CountMinSketch {
+	if epsilon <= 0 || epsilon >= 1 {
+		panic("NewCountMinSketch - epsilon must be from the (0, 1) interval")
+	}
+	if delta <= 0 || delta >= 1 {
+		panic("NewCountMinSketch - delta must be from the (0, 1) interval")
+	}
+	width := uint64(math.Ceil(math.E / epsilon))
+	depth := uint64(math.Ceil(math.Log(1 / delta)))
+	return &CountMinSketch{
+		counts: make([]uint64, width*depth),
+		width:  width,
+		depth:  depth,
+	}
+}
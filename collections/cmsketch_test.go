@@ -0,0 +1,107 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountMinSketchDimensions(t *testing.T) {
+	cms := NewCountMinSketch(0.001, 0.01)
+	assert.Equal(t, uint64(2719), cms.Width())
+	assert.Equal(t, uint64(5), cms.Depth())
+}
+
+func TestCountMinSketchEstimate(t *testing.T) {
+	cms := NewCountMinSketch(0.001, 0.01)
+	for i := 0; i < 1000; i++ {
+		cms.AddString(fmt.Sprintf("word-%d", i), uint64(i%10+1))
+	}
+	cms.AddString("the", 5000)
+	assert.Equal(t, uint64(10500), cms.Total())
+	maxErr := uint64(0.001 * float64(cms.Total()))
+	for i := 0; i < 1000; i++ {
+		est := cms.EstimateString(fmt.Sprintf("word-%d", i))
+		assert.GreaterOrEqual(t, est, uint64(i%10+1))
+		assert.LessOrEqual(t, est, uint64(i%10+1)+maxErr)
+	}
+	est := cms.EstimateString("the")
+	assert.GreaterOrEqual(t, est, uint64(5000))
+	assert.LessOrEqual(t, est, 5000+maxErr)
+	assert.LessOrEqual(t, cms.EstimateString("unknown"), maxErr)
+}
+
+func TestCountMinSketchMerge(t *testing.T) {
+	cms1 := NewCountMinSketch(0.01, 0.01)
+	cms2 := NewCountMinSketch(0.01, 0.01)
+	cms1.AddString("foo", 3)
+	cms2.AddString("foo", 4)
+	cms2.AddString("bar", 1)
+	assert.NoError(t, cms1.Merge(cms2))
+	assert.Equal(t, uint64(7), cms1.EstimateString("foo"))
+	assert.Equal(t, uint64(1), cms1.EstimateString("bar"))
+	assert.Equal(t, uint64(8), cms1.Total())
+
+	cms3 := NewCountMinSketch(0.1, 0.01)
+	assert.Error(t, cms1.Merge(cms3))
+}
+
+func TestCountMinSketchGob(t *testing.T) {
+	cms := NewCountMinSketch(0.01, 0.01)
+	cms.Add([]byte("foo"), 10)
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(cms))
+
+	var cms2 CountMinSketch
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(&cms2))
+	assert.Equal(t, uint64(10), cms2.Estimate([]byte("foo")))
+	assert.Equal(t, uint64(10), cms2.Total())
+	assert.Equal(t, cms.Width(), cms2.Width())
+	assert.Equal(t, cms.Depth(), cms2.Depth())
+}
+
+func TestCountMinSketchGobDecodeInvalidHeader(t *testing.T) {
+	for _, cms := range []*CountMinSketch{
+		{counts: []uint64{}, width: 0, depth: 4},
+		{counts: []uint64{}, width: 10, depth: 0},
+		{counts: []uint64{}, width: 1 << 33, depth: 1 << 31}, // width * depth overflows to 0
+		{counts: make([]uint64, 5), width: 2, depth: 3},
+	} {
+		data, err := cms.GobEncode()
+		assert.NoError(t, err)
+		var cms2 CountMinSketch
+		assert.Error(t, cms2.GobDecode(data))
+	}
+}
+
+func TestCountMinSketchReset(t *testing.T) {
+	cms := NewCountMinSketch(0.01, 0.01)
+	cms.AddString("foo", 10)
+	cms.Reset()
+	assert.Equal(t, uint64(0), cms.EstimateString("foo"))
+	assert.Equal(t, uint64(0), cms.Total())
+}
+
+func TestCountMinSketchInvalidParams(t *testing.T) {
+	assert.Panics(t, func() { NewCountMinSketch(0, 0.01) })
+	assert.Panics(t, func() { NewCountMinSketch(0.01, 1) })
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+// Summable is a constraint for types supporting the `+` operator
+// in a numeric sense.
+type Summable interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// SliceGroups is a result of grouping slice items by a key.
+// Groups are kept in the order of the first occurrence of their keys
+// in the original slice and items within each group keep their original
+// order too.
+type SliceGroups[K comparable, T any] struct {
+	keys   []K
+	groups map[K][]T
+}
+
+func (sg *SliceGroups[K, T]) add(k K, v T) {
+	curr, ok := sg.groups[k]
+	if !ok {
+		sg.keys = append(sg.keys, k)
+	}
+	sg.groups[k] = append(curr, v)
+}
+
+// Keys returns group keys in the order of their first occurrence
+func (sg *SliceGroups[K, T]) Keys() []K {
+	ans := make([]K, len(sg.keys))
+	copy(ans, sg.keys)
+	return ans
+}
+
+// Get returns a group with the key `k`. In case there is no
+// such group, nil is returned.
+func (sg *SliceGroups[K, T]) Get(k K) []T {
+	return sg.groups[k]
+}
+
+// Len returns number of groups
+func (sg *SliceGroups[K, T]) Len() int {
+	return len(sg.keys)
+}
+
+// Iterate goes through the groups in the order of their keys
+func (sg *SliceGroups[K, T]) Iterate(yield func(k K, group []T) bool) {
+	for _, k := range sg.keys {
+		if !yield(k, sg.groups[k]) {
+			return
+		}
+	}
+}
+
+// Counts returns sizes of all the groups (in the order of their keys)
+func (sg *SliceGroups[K, T]) Counts() []Pair[K, int] {
+	ans := make([]Pair[K, int], len(sg.keys))
+	for i, k := range sg.keys {
+		ans[i] = Pair[K, int]{First: k, Second: len(sg.groups[k])}
+	}
+	return ans
+}
+
+// ToSlices returns just the groups (in the order of their keys)
+// in the same format as SliceGroupBy does.
+func (sg *SliceGroups[K, T]) ToSlices() [][]T {
+	ans := make([][]T, len(sg.keys))
+	for i, k := range sg.keys {
+		ans[i] = sg.groups[k]
+	}
+	return ans
+}
+
+// SliceGroupByKey groups items of a slice by keys provided
+// by the `key` function. Unlike SliceGroupBy, the keys can be
+// of any comparable type and the order of the groups is guaranteed
+// (see SliceGroups).
+func SliceGroupByKey[T any, K comparable](items []T, key func(v T) K) *SliceGroups[K, T] {
+	ans := &SliceGroups[K, T]{
+		keys:   make([]K, 0, 10),
+		groups: make(map[K][]T),
+	}
+	for _, item := range items {
+		ans.add(key(item), item)
+	}
+	return ans
+}
+
+// SliceGroupByMulti groups items of a slice by a composite key
+// made of two partial keys. It is a shortcut for SliceGroupByKey
+// with a key function returning a Pair.
+func SliceGroupByMulti[T any, K1, K2 comparable](
+	items []T,
+	key1 func(v T) K1,
+	key2 func(v T) K2,
+) *SliceGroups[Pair[K1, K2], T] {
+	return SliceGroupByKey(items, func(v T) Pair[K1, K2] {
+		return Pair[K1, K2]{First: key1(v), Second: key2(v)}
+	})
+}
+
+// GroupsReduce reduces each group to a single value (see SliceReduce).
+// The result is in the order of the group keys.
+func GroupsReduce[K comparable, T any, U any](
+	groups *SliceGroups[K, T],
+	reduceFn func(acc U, curr T, i int) U,
+	initial U,
+) []Pair[K, U] {
+	ans := make([]Pair[K, U], len(groups.keys))
+	for i, k := range groups.keys {
+		ans[i] = Pair[K, U]{First: k, Second: SliceReduce(groups.groups[k], reduceFn, initial)}
+	}
+	return ans
+}
+
+// GroupsSum sums values provided by the `value` function within
+// each group. The result is in the order of the group keys.
+func GroupsSum[K comparable, T any, N Summable](
+	groups *SliceGroups[K, T],
+	value func(v T) N,
+) []Pair[K, N] {
+	return GroupsReduce(
+		groups,
+		func(acc N, curr T, i int) N {
+			return acc + value(curr)
+		},
+		0,
+	)
+}
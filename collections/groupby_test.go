@@ -0,0 +1,112 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testGroupables() []groupable {
+	return []groupable{
+		{Type: "B", Enabled: true, ID: 1},
+		{Type: "A", Enabled: true, ID: 2},
+		{Type: "B", Enabled: false, ID: 3},
+		{Type: "C", Enabled: true, ID: 4},
+		{Type: "A", Enabled: false, ID: 5},
+		{Type: "B", Enabled: true, ID: 6},
+	}
+}
+
+func TestSliceGroupByKey(t *testing.T) {
+	groups := SliceGroupByKey(testGroupables(), func(v groupable) string { return v.Type })
+	assert.Equal(t, 3, groups.Len())
+	assert.Equal(t, []string{"B", "A", "C"}, groups.Keys())
+	assert.Equal(
+		t,
+		[]groupable{{Type: "A", Enabled: true, ID: 2}, {Type: "A", Enabled: false, ID: 5}},
+		groups.Get("A"),
+	)
+	assert.Nil(t, groups.Get("X"))
+	ids := SliceMap(groups.ToSlices()[0], func(v groupable, i int) int { return v.ID })
+	assert.Equal(t, []int{1, 3, 6}, ids)
+}
+
+func TestSliceGroupByKeyNonStringKey(t *testing.T) {
+	groups := SliceGroupByKey([]int{1, 2, 3, 4, 5, 6, 7}, func(v int) int { return v % 3 })
+	assert.Equal(t, []int{1, 2, 0}, groups.Keys())
+	assert.Equal(t, []int{3, 6}, groups.Get(0))
+}
+
+func TestSliceGroupByKeyEmpty(t *testing.T) {
+	groups := SliceGroupByKey([]int{}, func(v int) int { return v })
+	assert.Equal(t, 0, groups.Len())
+	assert.Equal(t, []int{}, groups.Keys())
+	assert.Equal(t, [][]int{}, groups.ToSlices())
+}
+
+func TestSliceGroupsIterate(t *testing.T) {
+	groups := SliceGroupByKey(testGroupables(), func(v groupable) bool { return v.Enabled })
+	var keys []bool
+	var sizes []int
+	for k, g := range groups.Iterate {
+		keys = append(keys, k)
+		sizes = append(sizes, len(g))
+	}
+	assert.Equal(t, []bool{true, false}, keys)
+	assert.Equal(t, []int{4, 2}, sizes)
+}
+
+func TestSliceGroupsCounts(t *testing.T) {
+	groups := SliceGroupByKey(testGroupables(), func(v groupable) string { return v.Type })
+	assert.Equal(
+		t,
+		[]Pair[string, int]{{"B", 3}, {"A", 2}, {"C", 1}},
+		groups.Counts(),
+	)
+}
+
+func TestSliceGroupByMulti(t *testing.T) {
+	groups := SliceGroupByMulti(
+		testGroupables(),
+		func(v groupable) string { return v.Type },
+		func(v groupable) bool { return v.Enabled },
+	)
+	assert.Equal(t, 5, groups.Len())
+	assert.Equal(t, Pair[string, bool]{"B", true}, groups.Keys()[0])
+	assert.Equal(t, 2, len(groups.Get(Pair[string, bool]{"B", true})))
+	assert.Equal(t, 1, len(groups.Get(Pair[string, bool]{"B", false})))
+}
+
+func TestGroupsSum(t *testing.T) {
+	groups := SliceGroupByKey(testGroupables(), func(v groupable) string { return v.Type })
+	sums := GroupsSum(groups, func(v groupable) int { return v.ID })
+	assert.Equal(t, []Pair[string, int]{{"B", 10}, {"A", 7}, {"C", 4}}, sums)
+}
+
+func TestGroupsReduce(t *testing.T) {
+	groups := SliceGroupByKey(testGroupables(), func(v groupable) string { return v.Type })
+	maxIDs := GroupsReduce(
+		groups,
+		func(acc int, curr groupable, i int) int {
+			return max(acc, curr.ID)
+		},
+		-1,
+	)
+	assert.Equal(t, []Pair[string, int]{{"B", 6}, {"A", 5}, {"C", 4}}, maxIDs)
+}
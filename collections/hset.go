@@ -17,6 +17,7 @@
 package collections
 
 import (
+	"encoding/json"
 	"sort"
 )
 
@@ -88,7 +89,7 @@ func (set *HSet[T]) Iterate(yield func(item T) bool) {
 	}
 }
 
-func (set *HSet[T]) Union(other HSet[T]) *HSet[T] {
+func (set *HSet[T]) Union(other *HSet[T]) *HSet[T] {
 	set.testAndInit()
 	ans := NewHSet(set.ToSlice()...)
 	other.ForEach(func(item T) {
@@ -121,6 +122,103 @@ func (set *HSet[T]) Intersect(other *HSet[T]) *HSet[T] {
 	return ans
 }
 
+// SymmetricDifference returns a new set containing items
+// present in exactly one of the two sets.
+func (set *HSet[T]) SymmetricDifference(other *HSet[T]) *HSet[T] {
+	set.testAndInit()
+	ans := set.Sub(other)
+	other.ForEach(func(item T) {
+		if !set.Contains(item) {
+			ans.Add(item)
+		}
+	})
+	return ans
+}
+
+// IsSubset tests whether all the items of the set are
+// also contained in the `other` set.
+func (set *HSet[T]) IsSubset(other *HSet[T]) bool {
+	if set.Size() > other.Size() {
+		return false
+	}
+	for item := range set.Iterate {
+		if !other.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset tests whether the set contains all the items
+// of the `other` set.
+func (set *HSet[T]) IsSuperset(other *HSet[T]) bool {
+	return other.IsSubset(set)
+}
+
+// Equal tests whether both sets contain the same items
+func (set *HSet[T]) Equal(other *HSet[T]) bool {
+	return set.Size() == other.Size() && set.IsSubset(other)
+}
+
+// Disjoint tests whether the sets have no items in common
+func (set *HSet[T]) Disjoint(other *HSet[T]) bool {
+	smaller, larger := set, other
+	if smaller.Size() > larger.Size() {
+		smaller, larger = larger, smaller
+	}
+	for item := range smaller.Iterate {
+		if larger.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON encodes the set as a JSON array
+// with items in a stable order (see ToOrderedSlice).
+// The value receiver makes sure the method is used also
+// for sets stored as value fields of marshaled structs.
+func (set HSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.ToOrderedSlice())
+}
+
+// UnmarshalJSON decodes the set from a JSON array.
+// Possible duplicate items are merged.
+func (set *HSet[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	*set = *NewHSet(items...)
+	return nil
+}
+
+// HSetUnion creates a new set containing items of all
+// the provided sets.
+func HSetUnion[T Identifier](sets ...*HSet[T]) *HSet[T] {
+	ans := NewHSet[T]()
+	for _, s := range sets {
+		s.ForEach(func(item T) {
+			ans.Add(item)
+		})
+	}
+	return ans
+}
+
+// HSetIntersection creates a new set containing items
+// present in all the provided sets. For no sets, an empty
+// set is returned.
+func HSetIntersection[T Identifier](sets ...*HSet[T]) *HSet[T] {
+	if len(sets) == 0 {
+		return NewHSet[T]()
+	}
+	ans := NewHSet(sets[0].ToSlice()...)
+	for _, s := range sets[1:] {
+		ans = ans.Intersect(s)
+	}
+	return ans
+}
+
 func NewHSet[T Identifier](values ...T) *HSet[T] {
 	ans := HSet[T]{data: make(map[string]T)}
 	for _, v := range values {
@@ -17,6 +17,7 @@
 package collections
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -31,6 +32,19 @@ func (t testItem) ID() string {
 	return t.id
 }
 
+type jsonItem struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+func (t jsonItem) ID() string {
+	return t.Name
+}
+
+func hsetIDs[T Identifier](s *HSet[T]) []string {
+	return SliceMap(s.ToOrderedSlice(), func(v T, i int) string { return v.ID() })
+}
+
 func TestHSetSimpleInit(t *testing.T) {
 	s := HSet[testItem]{}
 	assert.Equal(t, 0, s.Size())
@@ -166,4 +180,63 @@ func TestHSetIntersect(t *testing.T) {
 	assert.True(t, s12.Contains(testItem{id: "2"}))
 	assert.True(t, s12.Contains(testItem{id: "3"}))
 	assert.Equal(t, 2, s12.Size())
-}
\ No newline at end of file
+}
+
+func TestHSetUnion(t *testing.T) {
+	s1 := NewHSet(testItem{id: "1"}, testItem{id: "2"})
+	s2 := NewHSet(testItem{id: "2"}, testItem{id: "3"})
+	assert.Equal(t, []string{"1", "2", "3"}, hsetIDs(s1.Union(s2)))
+}
+
+func TestHSetSymmetricDifference(t *testing.T) {
+	s1 := NewHSet(testItem{id: "1"}, testItem{id: "2"}, testItem{id: "3"})
+	s2 := NewHSet(testItem{id: "2"}, testItem{id: "3"}, testItem{id: "4"})
+	assert.Equal(t, []string{"1", "4"}, hsetIDs(s1.SymmetricDifference(s2)))
+}
+
+func TestHSetRelations(t *testing.T) {
+	s1 := NewHSet(testItem{id: "1"}, testItem{id: "2"})
+	s2 := NewHSet(testItem{id: "1"}, testItem{id: "2"}, testItem{id: "3"})
+	s3 := NewHSet(testItem{id: "4"})
+	assert.True(t, s1.IsSubset(s2))
+	assert.True(t, s2.IsSuperset(s1))
+	assert.False(t, s1.Equal(s2))
+	assert.True(t, s1.Equal(NewHSet(testItem{id: "2", data: "x"}, testItem{id: "1"})))
+	assert.True(t, s1.Disjoint(s3))
+	assert.False(t, s1.Disjoint(s2))
+}
+
+func TestHSetMultiUnionIntersection(t *testing.T) {
+	s1 := NewHSet(testItem{id: "1"}, testItem{id: "2"}, testItem{id: "3"})
+	s2 := NewHSet(testItem{id: "2"}, testItem{id: "3"}, testItem{id: "4"})
+	s3 := NewHSet(testItem{id: "3"}, testItem{id: "4"}, testItem{id: "5"})
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, hsetIDs(HSetUnion(s1, s2, s3)))
+	assert.Equal(t, []string{"3"}, hsetIDs(HSetIntersection(s1, s2, s3)))
+}
+
+func TestHSetJSON(t *testing.T) {
+	s := NewHSet(jsonItem{"foo", 1}, jsonItem{"bar", 2})
+	data, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"name":"bar","value":2},{"name":"foo","value":1}]`, string(data))
+
+	var s2 HSet[jsonItem]
+	err = json.Unmarshal(data, &s2)
+	assert.NoError(t, err)
+	assert.True(t, s.Equal(&s2))
+}
+
+func TestHSetJSONValueField(t *testing.T) {
+	type conf struct {
+		Items HSet[jsonItem] `json:"items"`
+	}
+	c := conf{Items: *NewHSet(jsonItem{"foo", 1})}
+	data, err := json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"items":[{"name":"foo","value":1}]}`, string(data))
+
+	var c2 conf
+	err = json.Unmarshal(data, &c2)
+	assert.NoError(t, err)
+	assert.True(t, c2.Items.Contains(jsonItem{"foo", 1}))
+}
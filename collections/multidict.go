@@ -16,32 +16,117 @@
 
 package collections
 
-type Multidict[T any] struct {
-	data map[string][]T
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+)
+
+// Multidict is a map where each key can hold multiple values.
+// The values of a key are kept in the order they were added.
+//
+// Operations which compare values (Remove and Add in case of UniqValues)
+// use the == operator (via `any`) so they panic for values of non-comparable
+// types. For such types, please use NewMultidictFunc.
+type Multidict[K comparable, T any] struct {
+	data  map[K][]T
+	equal func(a, b T) bool
+
+	// UniqValues if true then Add won't store a value already
+	// present for the respective key.
+	// It can be enabled at any time during operation. The
+	// effect then starts with the next call of the Add method.
+	UniqValues bool
 }
 
-func (md *Multidict[T]) Add(k string, v T) {
+func (md *Multidict[K, T]) testAndInit() {
 	if md.data == nil {
-		md.data = make(map[string][]T)
+		md.data = make(map[K][]T)
 	}
-	_, ok := md.data[k]
-	if !ok {
-		md.data[k] = make([]T, 0, 10)
+}
+
+func (md *Multidict[K, T]) valuesEqual(a, b T) bool {
+	if md.equal != nil {
+		return md.equal(a, b)
 	}
-	md.data[k] = append(md.data[k], v)
+	return any(a) == any(b)
 }
 
-func (md *Multidict[T]) Get(k string) []T {
-	if md.data == nil {
-		md.data = make(map[string][]T)
+func (md *Multidict[K, T]) Add(k K, v T) {
+	md.testAndInit()
+	curr, ok := md.data[k]
+	if !ok {
+		curr = make([]T, 0, 10)
+
+	} else if md.UniqValues && slices.ContainsFunc(curr, func(item T) bool {
+		return md.valuesEqual(item, v)
+	}) {
+		return
 	}
+	md.data[k] = append(curr, v)
+}
+
+func (md *Multidict[K, T]) Get(k K) []T {
+	md.testAndInit()
 	return md.data[k]
 }
 
-func (md *Multidict[T]) Iterate(yield func(k string, v []T) bool) {
-	if md.data == nil {
-		md.data = make(map[string][]T)
+// Remove removes all the occurrences of the value `v` stored
+// under the key `k`. In case there are no more values for the key,
+// the key is removed too. The method returns true if anything
+// has been removed.
+func (md *Multidict[K, T]) Remove(k K, v T) bool {
+	md.testAndInit()
+	curr, ok := md.data[k]
+	if !ok {
+		return false
+	}
+	origLen := len(curr)
+	curr = slices.DeleteFunc(curr, func(item T) bool {
+		return md.valuesEqual(item, v)
+	})
+	if len(curr) == 0 {
+		delete(md.data, k)
+
+	} else {
+		md.data[k] = curr
+	}
+	return len(curr) < origLen
+}
+
+// RemoveAll removes the key `k` along with all its values
+func (md *Multidict[K, T]) RemoveAll(k K) {
+	md.testAndInit()
+	delete(md.data, k)
+}
+
+// Keys returns all the keys of the dictionary. The order
+// of the keys is not guaranteed.
+func (md *Multidict[K, T]) Keys() []K {
+	md.testAndInit()
+	ans := make([]K, 0, len(md.data))
+	for k := range md.data {
+		ans = append(ans, k)
+	}
+	return ans
+}
+
+// Len returns number of keys in the dictionary
+func (md *Multidict[K, T]) Len() int {
+	return len(md.data)
+}
+
+// CountValues returns number of all the values in the dictionary
+func (md *Multidict[K, T]) CountValues() int {
+	var ans int
+	for _, v := range md.data {
+		ans += len(v)
 	}
+	return ans
+}
+
+func (md *Multidict[K, T]) Iterate(yield func(k K, v []T) bool) {
+	md.testAndInit()
 	for k, v := range md.data {
 		if !yield(k, v) {
 			return
@@ -49,10 +134,8 @@ func (md *Multidict[T]) Iterate(yield func(k string, v []T) bool) {
 	}
 }
 
-func (md *Multidict[T]) IterateFlat(yield func(k string, v T) bool) {
-	if md.data == nil {
-		md.data = make(map[string][]T)
-	}
+func (md *Multidict[K, T]) IterateFlat(yield func(k K, v T) bool) {
+	md.testAndInit()
 	for k, v := range md.data {
 		for _, v2 := range v {
 			if !yield(k, v2) {
@@ -62,8 +145,163 @@ func (md *Multidict[T]) IterateFlat(yield func(k string, v T) bool) {
 	}
 }
 
-func NewMultidict[T any]() *Multidict[T] {
-	return &Multidict[T]{
-		data: make(map[string][]T),
+// MarshalJSON encodes the dictionary as a JSON object
+// with arrays of values.
+// The value receiver makes sure the method is used also
+// for dictionaries stored as value fields of marshaled structs.
+func (md Multidict[K, T]) MarshalJSON() ([]byte, error) {
+	md.testAndInit()
+	return json.Marshal(md.data)
+}
+
+// UnmarshalJSON decodes the dictionary from a JSON object with
+// arrays of values. Other settings (UniqValues, equality function)
+// are preserved but the UniqValues is not applied to the decoded data.
+func (md *Multidict[K, T]) UnmarshalJSON(data []byte) error {
+	data2 := make(map[K][]T)
+	if err := json.Unmarshal(data, &data2); err != nil {
+		return err
+	}
+	md.data = data2
+	return nil
+}
+
+func NewMultidict[K comparable, T any]() *Multidict[K, T] {
+	return &Multidict[K, T]{
+		data: make(map[K][]T),
+	}
+}
+
+// NewMultidictFunc creates a new Multidict which compares values
+// using the provided function.
+func NewMultidictFunc[K comparable, T any](equal func(a, b T) bool) *Multidict[K, T] {
+	return &Multidict[K, T]{
+		data:  make(map[K][]T),
+		equal: equal,
+	}
+}
+
+// ----------------------------------------------------
+
+// ConcurrentMultidict is a concurrency-safe wrapper around
+// Multidict. All the returned slices are copies so they can
+// be used without any synchronization.
+type ConcurrentMultidict[K comparable, T any] struct {
+	mutex sync.RWMutex
+	md    *Multidict[K, T]
+}
+
+func (cmd *ConcurrentMultidict[K, T]) Add(k K, v T) {
+	cmd.mutex.Lock()
+	defer cmd.mutex.Unlock()
+	cmd.md.Add(k, v)
+}
+
+func (cmd *ConcurrentMultidict[K, T]) Get(k K) []T {
+	cmd.mutex.RLock()
+	defer cmd.mutex.RUnlock()
+	return slices.Clone(cmd.md.data[k])
+}
+
+// Remove - see Multidict.Remove
+func (cmd *ConcurrentMultidict[K, T]) Remove(k K, v T) bool {
+	cmd.mutex.Lock()
+	defer cmd.mutex.Unlock()
+	return cmd.md.Remove(k, v)
+}
+
+// RemoveAll removes the key `k` along with all its values
+func (cmd *ConcurrentMultidict[K, T]) RemoveAll(k K) {
+	cmd.mutex.Lock()
+	defer cmd.mutex.Unlock()
+	cmd.md.RemoveAll(k)
+}
+
+// SetUniqValues - see Multidict.UniqValues
+func (cmd *ConcurrentMultidict[K, T]) SetUniqValues(v bool) {
+	cmd.mutex.Lock()
+	defer cmd.mutex.Unlock()
+	cmd.md.UniqValues = v
+}
+
+func (cmd *ConcurrentMultidict[K, T]) Keys() []K {
+	cmd.mutex.RLock()
+	defer cmd.mutex.RUnlock()
+	return cmd.md.Keys()
+}
+
+// Len returns number of keys in the dictionary
+func (cmd *ConcurrentMultidict[K, T]) Len() int {
+	cmd.mutex.RLock()
+	defer cmd.mutex.RUnlock()
+	return cmd.md.Len()
+}
+
+// CountValues returns number of all the values in the dictionary
+func (cmd *ConcurrentMultidict[K, T]) CountValues() int {
+	cmd.mutex.RLock()
+	defer cmd.mutex.RUnlock()
+	return cmd.md.CountValues()
+}
+
+// snapshot returns a deep copy of the wrapped data
+func (cmd *ConcurrentMultidict[K, T]) snapshot() map[K][]T {
+	cmd.mutex.RLock()
+	defer cmd.mutex.RUnlock()
+	ans := make(map[K][]T, len(cmd.md.data))
+	for k, v := range cmd.md.data {
+		ans[k] = slices.Clone(v)
+	}
+	return ans
+}
+
+// Iterate goes through all the keys and their values. It operates on
+// a snapshot taken at the time of the call so it is safe to modify
+// the dictionary from within the yield function.
+func (cmd *ConcurrentMultidict[K, T]) Iterate(yield func(k K, v []T) bool) {
+	for k, v := range cmd.snapshot() {
+		if !yield(k, v) {
+			return
+		}
+	}
+}
+
+// IterateFlat goes through all the key-value pairs. It operates on
+// a snapshot taken at the time of the call so it is safe to modify
+// the dictionary from within the yield function.
+func (cmd *ConcurrentMultidict[K, T]) IterateFlat(yield func(k K, v T) bool) {
+	for k, v := range cmd.snapshot() {
+		for _, v2 := range v {
+			if !yield(k, v2) {
+				return
+			}
+		}
+	}
+}
+
+func (cmd *ConcurrentMultidict[K, T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cmd.snapshot())
+}
+
+func (cmd *ConcurrentMultidict[K, T]) UnmarshalJSON(data []byte) error {
+	cmd.mutex.Lock()
+	defer cmd.mutex.Unlock()
+	if cmd.md == nil {
+		cmd.md = NewMultidict[K, T]()
+	}
+	return cmd.md.UnmarshalJSON(data)
+}
+
+func NewConcurrentMultidict[K comparable, T any]() *ConcurrentMultidict[K, T] {
+	return &ConcurrentMultidict[K, T]{
+		md: NewMultidict[K, T](),
+	}
+}
+
+// NewConcurrentMultidictFunc creates a new ConcurrentMultidict which
+// compares values using the provided function.
+func NewConcurrentMultidictFunc[K comparable, T any](equal func(a, b T) bool) *ConcurrentMultidict[K, T] {
+	return &ConcurrentMultidict[K, T]{
+		md: NewMultidictFunc[K](equal),
 	}
 }
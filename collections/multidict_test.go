@@ -17,6 +17,7 @@
 package collections
 
 import (
+	"encoding/json"
 	"sort"
 	"strings"
 	"testing"
@@ -25,14 +26,14 @@ import (
 )
 
 func TestMultiDictAutoInitializes(t *testing.T) {
-	var md Multidict[string]
+	var md Multidict[string, string]
 	assert.NotPanics(t, func() {
 		md.Add("foo", "foo-value")
 	})
 }
 
 func TestMultidictSetGet(t *testing.T) {
-	md := NewMultidict[string]()
+	md := NewMultidict[string, string]()
 	md.Add("foo", "foo-value")
 	v := md.Get("foo")
 	assert.Equal(t, []string{"foo-value"}, v)
@@ -44,7 +45,7 @@ type mdItem struct {
 }
 
 func TestMultidictIterate(t *testing.T) {
-	md := NewMultidict[string]()
+	md := NewMultidict[string, string]()
 	md.Add("foo", "foo-v1")
 	md.Add("foo", "foo-v2")
 	md.Add("bar", "bar-v1")
@@ -65,7 +66,7 @@ type mdFlatItem struct {
 }
 
 func TestMultidictIterateFlat(t *testing.T) {
-	md := NewMultidict[string]()
+	md := NewMultidict[string, string]()
 	md.Add("foo", "foo-v1")
 	md.Add("foo", "foo-v2")
 	md.Add("bar", "bar-v1")
@@ -79,3 +80,119 @@ func TestMultidictIterateFlat(t *testing.T) {
 	})
 	assert.Equal(t, []mdFlatItem{{"foo", "foo-v1"}, {"foo", "foo-v2"}, {"bar", "bar-v1"}}, itemTest)
 }
+
+func TestMultidictRemove(t *testing.T) {
+	md := NewMultidict[string, int]()
+	md.Add("foo", 1)
+	md.Add("foo", 2)
+	md.Add("foo", 1)
+	md.Add("bar", 3)
+	assert.True(t, md.Remove("foo", 1))
+	assert.Equal(t, []int{2}, md.Get("foo"))
+	assert.False(t, md.Remove("foo", 10))
+	assert.False(t, md.Remove("baz", 1))
+	assert.True(t, md.Remove("bar", 3))
+	assert.Equal(t, []string{"foo"}, md.Keys())
+}
+
+func TestMultidictRemoveAll(t *testing.T) {
+	md := NewMultidict[string, int]()
+	md.Add("foo", 1)
+	md.Add("foo", 2)
+	md.Add("bar", 3)
+	md.RemoveAll("foo")
+	assert.Nil(t, md.Get("foo"))
+	assert.Equal(t, 1, md.Len())
+}
+
+func TestMultidictCounting(t *testing.T) {
+	md := NewMultidict[int, string]()
+	md.Add(1, "a")
+	md.Add(1, "b")
+	md.Add(2, "c")
+	assert.Equal(t, 2, md.Len())
+	assert.Equal(t, 3, md.CountValues())
+	assert.ElementsMatch(t, []int{1, 2}, md.Keys())
+}
+
+func TestMultidictUniqValues(t *testing.T) {
+	md := NewMultidict[string, string]()
+	md.UniqValues = true
+	md.Add("foo", "a")
+	md.Add("foo", "b")
+	md.Add("foo", "a")
+	assert.Equal(t, []string{"a", "b"}, md.Get("foo"))
+}
+
+func TestMultidictFunc(t *testing.T) {
+	md := NewMultidictFunc[string](func(a, b []string) bool {
+		return len(a) == len(b)
+	})
+	md.UniqValues = true
+	md.Add("foo", []string{"a"})
+	md.Add("foo", []string{"b"})
+	md.Add("foo", []string{"c", "d"})
+	assert.Equal(t, [][]string{{"a"}, {"c", "d"}}, md.Get("foo"))
+	md.Remove("foo", []string{"x", "y"})
+	assert.Equal(t, [][]string{{"a"}}, md.Get("foo"))
+}
+
+func TestMultidictJSON(t *testing.T) {
+	md := NewMultidict[string, int]()
+	md.Add("foo", 1)
+	md.Add("foo", 2)
+	data, err := json.Marshal(md)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"foo":[1,2]}`, string(data))
+
+	var md2 Multidict[string, int]
+	err = json.Unmarshal([]byte(`{"bar": [3, 4]}`), &md2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, md2.Get("bar"))
+}
+
+func TestMultidictJSONValueField(t *testing.T) {
+	type conf struct {
+		Aliases Multidict[string, string] `json:"aliases"`
+	}
+	c := conf{Aliases: *NewMultidict[string, string]()}
+	c.Aliases.Add("foo", "bar")
+	data, err := json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"aliases":{"foo":["bar"]}}`, string(data))
+}
+
+func TestConcurrentMultidict(t *testing.T) {
+	md := NewConcurrentMultidict[string, int]()
+	md.SetUniqValues(true)
+	md.Add("foo", 1)
+	md.Add("foo", 1)
+	md.Add("foo", 2)
+	md.Add("bar", 3)
+	assert.Equal(t, []int{1, 2}, md.Get("foo"))
+	assert.Equal(t, 2, md.Len())
+	assert.Equal(t, 3, md.CountValues())
+
+	for k := range md.Iterate {
+		md.RemoveAll(k) // must not deadlock
+	}
+	assert.Equal(t, 0, md.Len())
+}
+
+func TestConcurrentMultidictGetReturnsCopy(t *testing.T) {
+	md := NewConcurrentMultidict[string, int]()
+	md.Add("foo", 1)
+	v := md.Get("foo")
+	v[0] = 100
+	assert.Equal(t, []int{1}, md.Get("foo"))
+}
+
+func TestConcurrentMultidictJSON(t *testing.T) {
+	var md ConcurrentMultidict[string, int]
+	err := json.Unmarshal([]byte(`{"foo": [1, 2]}`), &md)
+	assert.NoError(t, err)
+	assert.True(t, md.Remove("foo", 1))
+	data, err := json.Marshal(&md)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"foo":[2]}`, string(data))
+}
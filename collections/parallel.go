@@ -0,0 +1,205 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+type ParallelErrorMode int
+
+const (
+	// ParallelFirstError stops processing as soon as any call
+	// fails and returns the first error which occurred.
+	ParallelFirstError ParallelErrorMode = iota
+
+	// ParallelCollectErrors processes all the items no matter
+	// the errors and returns all the errors joined (see errors.Join)
+	// in the order of the respective items.
+	ParallelCollectErrors
+)
+
+type parallelConf struct {
+	concurrency int
+	errorMode   ParallelErrorMode
+}
+
+// WithParallelConcurrency sets the maximum number of items processed
+// at the same time. Values less than 1 are ignored.
+// The default is runtime.GOMAXPROCS(0).
+func WithParallelConcurrency(value int) func(conf *parallelConf) {
+	return func(conf *parallelConf) {
+		if value > 0 {
+			conf.concurrency = value
+		}
+	}
+}
+
+// WithParallelErrorMode sets how errors returned by the processing
+// functions are handled. The default is ParallelFirstError.
+func WithParallelErrorMode(value ParallelErrorMode) func(conf *parallelConf) {
+	return func(conf *parallelConf) {
+		conf.errorMode = value
+	}
+}
+
+// parallelRun calls `fn` for indices 0...n-1 using a bounded number
+// of workers. Once the context is cancelled (or any call fails
+// in case of the ParallelFirstError mode), no more items are started.
+func parallelRun(
+	ctx context.Context,
+	n int,
+	fn func(ctx context.Context, i int) error,
+	options []func(conf *parallelConf),
+) error {
+	conf := parallelConf{concurrency: runtime.GOMAXPROCS(0)}
+	for _, opt := range options {
+		opt(&conf)
+	}
+	ctx2, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make([]error, n)
+	var firstErr error
+	var firstErrOnce sync.Once
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < min(conf.concurrency, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx2.Err() == nil {
+				i := int(next.Add(1)) - 1
+				if i >= n {
+					return
+				}
+				if err := fn(ctx2, i); err != nil {
+					errs[i] = err
+					if conf.errorMode == ParallelFirstError {
+						firstErrOnce.Do(func() {
+							firstErr = err
+						})
+						cancel()
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if conf.errorMode == ParallelFirstError {
+		if firstErr != nil {
+			return firstErr
+		}
+		return ctx.Err()
+	}
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// ParallelSliceMap is a concurrent variant of SliceMap. The `mapFn`
+// is called from multiple goroutines (see WithParallelConcurrency)
+// but the order of the result corresponds with the order of `data`.
+// In case of an error (or cancelled context), the function returns
+// nil result along with the error (see WithParallelErrorMode).
+func ParallelSliceMap[T any, U any](
+	ctx context.Context,
+	data []T,
+	mapFn func(ctx context.Context, v T, i int) (U, error),
+	options ...func(conf *parallelConf),
+) ([]U, error) {
+	ans := make([]U, len(data))
+	err := parallelRun(
+		ctx,
+		len(data),
+		func(ctx context.Context, i int) error {
+			v, err := mapFn(ctx, data[i], i)
+			if err != nil {
+				return err
+			}
+			ans[i] = v
+			return nil
+		},
+		options,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ans, nil
+}
+
+// ParallelSliceFilter is a concurrent variant of SliceFilter. The `filterFn`
+// is called from multiple goroutines (see WithParallelConcurrency) but
+// the result keeps the original order of the items.
+// In case of an error (or cancelled context), the function returns
+// nil result along with the error (see WithParallelErrorMode).
+func ParallelSliceFilter[T any](
+	ctx context.Context,
+	data []T,
+	filterFn func(ctx context.Context, v T, i int) (bool, error),
+	options ...func(conf *parallelConf),
+) ([]T, error) {
+	keep := make([]bool, len(data))
+	err := parallelRun(
+		ctx,
+		len(data),
+		func(ctx context.Context, i int) error {
+			ok, err := filterFn(ctx, data[i], i)
+			if err != nil {
+				return err
+			}
+			keep[i] = ok
+			return nil
+		},
+		options,
+	)
+	if err != nil {
+		return nil, err
+	}
+	ans := make([]T, 0, len(data))
+	for i, v := range data {
+		if keep[i] {
+			ans = append(ans, v)
+		}
+	}
+	return ans, nil
+}
+
+// ParallelForEach calls `fn` on all the items of `data` from multiple
+// goroutines (see WithParallelConcurrency). The function returns once
+// all the started calls are finished.
+func ParallelForEach[T any](
+	ctx context.Context,
+	data []T,
+	fn func(ctx context.Context, v T, i int) error,
+	options ...func(conf *parallelConf),
+) error {
+	return parallelRun(
+		ctx,
+		len(data),
+		func(ctx context.Context, i int) error {
+			return fn(ctx, data[i], i)
+		},
+		options,
+	)
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelSliceMapKeepsOrder(t *testing.T) {
+	data := make([]int, 100)
+	for i := range data {
+		data[i] = i
+	}
+	ans, err := ParallelSliceMap(
+		context.Background(),
+		data,
+		func(ctx context.Context, v int, i int) (string, error) {
+			time.Sleep(time.Duration(100-i) * time.Microsecond)
+			return fmt.Sprintf("%d:%d", i, v*2), nil
+		},
+		WithParallelConcurrency(8),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, len(ans))
+	for i, v := range ans {
+		assert.Equal(t, fmt.Sprintf("%d:%d", i, i*2), v)
+	}
+}
+
+func TestParallelSliceMapEmpty(t *testing.T) {
+	ans, err := ParallelSliceMap(
+		context.Background(),
+		[]int{},
+		func(ctx context.Context, v int, i int) (int, error) { return v, nil },
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{}, ans)
+}
+
+func TestParallelConcurrencyLimit(t *testing.T) {
+	var active, maxActive atomic.Int32
+	err := ParallelForEach(
+		context.Background(),
+		make([]int, 50),
+		func(ctx context.Context, v int, i int) error {
+			curr := active.Add(1)
+			for {
+				m := maxActive.Load()
+				if curr <= m || maxActive.CompareAndSwap(m, curr) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			active.Add(-1)
+			return nil
+		},
+		WithParallelConcurrency(3),
+	)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, maxActive.Load(), int32(3))
+	assert.Greater(t, maxActive.Load(), int32(0))
+}
+
+func TestParallelSliceFilter(t *testing.T) {
+	ans, err := ParallelSliceFilter(
+		context.Background(),
+		[]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		func(ctx context.Context, v int, i int) (bool, error) {
+			return v%3 == 0, nil
+		},
+		WithParallelConcurrency(4),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 3, 6, 9}, ans)
+}
+
+func TestParallelFirstErrorStopsProcessing(t *testing.T) {
+	errTest := errors.New("test error")
+	var numCalls atomic.Int32
+	ans, err := ParallelSliceMap(
+		context.Background(),
+		make([]int, 1000),
+		func(ctx context.Context, v int, i int) (int, error) {
+			numCalls.Add(1)
+			if i == 5 {
+				return 0, errTest
+			}
+			return v, nil
+		},
+		WithParallelConcurrency(1),
+	)
+	assert.ErrorIs(t, err, errTest)
+	assert.Nil(t, ans)
+	assert.Equal(t, int32(6), numCalls.Load())
+}
+
+func TestParallelCollectErrors(t *testing.T) {
+	err1 := errors.New("error 1")
+	err2 := errors.New("error 2")
+	var numCalls atomic.Int32
+	err := ParallelForEach(
+		context.Background(),
+		make([]int, 20),
+		func(ctx context.Context, v int, i int) error {
+			numCalls.Add(1)
+			if i == 3 {
+				return err1
+			}
+			if i == 15 {
+				return err2
+			}
+			return nil
+		},
+		WithParallelConcurrency(4),
+		WithParallelErrorMode(ParallelCollectErrors),
+	)
+	assert.ErrorIs(t, err, err1)
+	assert.ErrorIs(t, err, err2)
+	assert.Equal(t, "error 1\nerror 2", err.Error())
+	assert.Equal(t, int32(20), numCalls.Load())
+}
+
+func TestParallelContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var numCalls atomic.Int32
+	err := ParallelForEach(
+		ctx,
+		make([]int, 1000),
+		func(ctx context.Context, v int, i int) error {
+			if numCalls.Add(1) == 10 {
+				cancel()
+			}
+			return nil
+		},
+		WithParallelConcurrency(2),
+	)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, numCalls.Load(), int32(1000))
+}
@@ -0,0 +1,237 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"cmp"
+	"slices"
+)
+
+// PriorityQueue is a binary heap based queue where the "smallest"
+// item (according to the provided comparison function) is always
+// on top. To obtain a max-queue, just reverse the comparison function.
+//
+// Methods Fix and Remove work with item positions which can
+// be obtained via the FindIndex method.
+type PriorityQueue[T any] struct {
+	items []T
+	cmp   func(a, b T) int
+}
+
+func (pq *PriorityQueue[T]) less(i, j int) bool {
+	return pq.cmp(pq.items[i], pq.items[j]) < 0
+}
+
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+}
+
+func (pq *PriorityQueue[T]) up(j int) {
+	for j > 0 {
+		i := (j - 1) / 2
+		if !pq.less(j, i) {
+			break
+		}
+		pq.swap(i, j)
+		j = i
+	}
+}
+
+// down returns true if the item at `i` has moved
+func (pq *PriorityQueue[T]) down(i int) bool {
+	i0 := i
+	n := len(pq.items)
+	for {
+		j := 2*i + 1
+		if j >= n {
+			break
+		}
+		if j2 := j + 1; j2 < n && pq.less(j2, j) {
+			j = j2
+		}
+		if !pq.less(j, i) {
+			break
+		}
+		pq.swap(i, j)
+		i = j
+	}
+	return i > i0
+}
+
+// Push adds a new item to the queue
+func (pq *PriorityQueue[T]) Push(v T) {
+	pq.items = append(pq.items, v)
+	pq.up(len(pq.items) - 1)
+}
+
+// Pop removes and returns the top item. The second
+// returned value is false if the queue is empty.
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	if len(pq.items) == 0 {
+		var zeroVal T
+		return zeroVal, false
+	}
+	return pq.Remove(0), true
+}
+
+// Peek returns the top item without removing it. The second
+// returned value is false if the queue is empty.
+func (pq *PriorityQueue[T]) Peek() (T, bool) {
+	if len(pq.items) == 0 {
+		var zeroVal T
+		return zeroVal, false
+	}
+	return pq.items[0], true
+}
+
+// FindIndex returns a position of the first item (in the internal
+// order) matching the `pred`. If nothing is found, -1 is returned.
+// The position is valid only until the queue is modified.
+func (pq *PriorityQueue[T]) FindIndex(pred func(v T) bool) int {
+	return slices.IndexFunc(pq.items, pred)
+}
+
+// Fix restores the heap ordering after the item at the position `i`
+// has changed its priority. The item can be either modified
+// in place (for pointer types) or replaced by `v`.
+func (pq *PriorityQueue[T]) Fix(i int, v T) {
+	pq.items[i] = v
+	if !pq.down(i) {
+		pq.up(i)
+	}
+}
+
+// Remove removes and returns the item at the position `i`.
+// The method panics in case the position is out of range.
+func (pq *PriorityQueue[T]) Remove(i int) T {
+	n := len(pq.items) - 1
+	ans := pq.items[i]
+	pq.swap(i, n)
+	var zeroVal T
+	pq.items[n] = zeroVal // let GC do its job
+	pq.items = pq.items[:n]
+	if i < n && !pq.down(i) {
+		pq.up(i)
+	}
+	return ans
+}
+
+// Len returns number of items in the queue
+func (pq *PriorityQueue[T]) Len() int {
+	return len(pq.items)
+}
+
+// Iterate goes through the items in the order they would be popped
+// from the queue. The queue itself is not modified (the iteration
+// works with an internal copy which is consumed lazily).
+func (pq *PriorityQueue[T]) Iterate(yield func(i int, v T) bool) {
+	tmp := PriorityQueue[T]{
+		items: slices.Clone(pq.items),
+		cmp:   pq.cmp,
+	}
+	for i := 0; tmp.Len() > 0; i++ {
+		v, _ := tmp.Pop()
+		if !yield(i, v) {
+			return
+		}
+	}
+}
+
+// NewPriorityQueue creates a new PriorityQueue with items ordered
+// by the provided comparison function (the rules for the function
+// are the same as in slices.SortFunc). The queue pops the smallest
+// items first.
+func NewPriorityQueue[T any](cmp func(a, b T) int) *PriorityQueue[T] {
+	return &PriorityQueue[T]{
+		items: make([]T, 0, 10),
+		cmp:   cmp,
+	}
+}
+
+// NewOrderedPriorityQueue creates a new PriorityQueue for ordered
+// types which pops the smallest items first.
+func NewOrderedPriorityQueue[T cmp.Ordered]() *PriorityQueue[T] {
+	return NewPriorityQueue(cmp.Compare[T])
+}
+
+// ----------------------------------------------------
+
+// TopK collects K "best" (i.e. the largest according to the provided
+// comparison function) items from a stream of values while keeping
+// only those K items in memory.
+type TopK[T any] struct {
+	queue *PriorityQueue[T]
+	k     int
+}
+
+// Add offers a new value to the collector. The value is kept only if
+// there is still room for it or if it is better than the worst kept item.
+// The method returns true if the value has been kept.
+func (tk *TopK[T]) Add(v T) bool {
+	if tk.k == 0 {
+		return false
+	}
+	if tk.queue.Len() < tk.k {
+		tk.queue.Push(v)
+		return true
+	}
+	if tk.queue.cmp(v, tk.queue.items[0]) <= 0 {
+		return false
+	}
+	tk.queue.Fix(0, v)
+	return true
+}
+
+// Len returns number of currently kept items
+func (tk *TopK[T]) Len() int {
+	return tk.queue.Len()
+}
+
+// Iterate goes through the kept items from the best to the worst one
+func (tk *TopK[T]) Iterate(yield func(i int, v T) bool) {
+	for i, v := range tk.Result() {
+		if !yield(i, v) {
+			return
+		}
+	}
+}
+
+// Result returns the kept items sorted from the best to the worst one
+func (tk *TopK[T]) Result() []T {
+	ans := slices.Clone(tk.queue.items)
+	slices.SortFunc(ans, func(a, b T) int {
+		return tk.queue.cmp(b, a)
+	})
+	return ans
+}
+
+// NewTopK creates a new TopK collector keeping `k` largest items
+// according to the provided comparison function (the rules for
+// the function are the same as in slices.SortFunc).
+// The function panics if k is negative.
+func NewTopK[T any](k int, cmp func(a, b T) int) *TopK[T] {
+	if k < 0 {
+		panic("NewTopK - k must not be negative")
+	}
+	return &TopK[T]{
+		queue: &PriorityQueue[T]{
+			items: make([]T, 0, k),
+			cmp:   cmp,
+		},
+		k: k,
+	}
+}
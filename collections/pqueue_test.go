@@ -0,0 +1,155 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type pqItem struct {
+	Word string
+	Freq int
+}
+
+func popAll[T any](pq *PriorityQueue[T]) []T {
+	ans := make([]T, 0, pq.Len())
+	for pq.Len() > 0 {
+		v, _ := pq.Pop()
+		ans = append(ans, v)
+	}
+	return ans
+}
+
+func TestPriorityQueuePushPop(t *testing.T) {
+	pq := NewOrderedPriorityQueue[int]()
+	for _, v := range []int{5, 3, 8, 1, 9, 2, 7} {
+		pq.Push(v)
+	}
+	assert.Equal(t, 7, pq.Len())
+	v, ok := pq.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, []int{1, 2, 3, 5, 7, 8, 9}, popAll(pq))
+}
+
+func TestPriorityQueueEmpty(t *testing.T) {
+	pq := NewOrderedPriorityQueue[int]()
+	_, ok := pq.Pop()
+	assert.False(t, ok)
+	_, ok = pq.Peek()
+	assert.False(t, ok)
+}
+
+func TestPriorityQueueMaxOrder(t *testing.T) {
+	pq := NewPriorityQueue(func(a, b pqItem) int { return cmp.Compare(b.Freq, a.Freq) })
+	pq.Push(pqItem{"a", 10})
+	pq.Push(pqItem{"b", 30})
+	pq.Push(pqItem{"c", 20})
+	v, _ := pq.Pop()
+	assert.Equal(t, "b", v.Word)
+}
+
+func TestPriorityQueueRandomized(t *testing.T) {
+	pq := NewOrderedPriorityQueue[int]()
+	data := rand.Perm(500)
+	for _, v := range data {
+		pq.Push(v)
+	}
+	slices.Sort(data)
+	assert.Equal(t, data, popAll(pq))
+}
+
+func TestPriorityQueueFix(t *testing.T) {
+	pq := NewPriorityQueue(func(a, b *pqItem) int { return cmp.Compare(a.Freq, b.Freq) })
+	items := []*pqItem{{"a", 10}, {"b", 20}, {"c", 30}, {"d", 40}}
+	for _, v := range items {
+		pq.Push(v)
+	}
+	idx := pq.FindIndex(func(v *pqItem) bool { return v.Word == "d" })
+	items[3].Freq = 5
+	pq.Fix(idx, items[3])
+	v, _ := pq.Peek()
+	assert.Equal(t, "d", v.Word)
+
+	idx = pq.FindIndex(func(v *pqItem) bool { return v.Word == "d" })
+	pq.Fix(idx, &pqItem{"e", 50})
+	words := SliceMap(popAll(pq), func(v *pqItem, i int) string { return v.Word })
+	assert.Equal(t, []string{"a", "b", "c", "e"}, words)
+}
+
+func TestPriorityQueueRemove(t *testing.T) {
+	pq := NewOrderedPriorityQueue[int]()
+	for _, v := range []int{5, 3, 8, 1, 9} {
+		pq.Push(v)
+	}
+	idx := pq.FindIndex(func(v int) bool { return v == 5 })
+	assert.Equal(t, 5, pq.Remove(idx))
+	assert.Equal(t, -1, pq.FindIndex(func(v int) bool { return v == 5 }))
+	assert.Equal(t, []int{1, 3, 8, 9}, popAll(pq))
+}
+
+func TestPriorityQueueIterate(t *testing.T) {
+	pq := NewOrderedPriorityQueue[int]()
+	for _, v := range []int{5, 3, 8, 1} {
+		pq.Push(v)
+	}
+	var ans []int
+	for _, v := range pq.Iterate {
+		ans = append(ans, v)
+	}
+	assert.Equal(t, []int{1, 3, 5, 8}, ans)
+	assert.Equal(t, 4, pq.Len())
+}
+
+func TestTopK(t *testing.T) {
+	tk := NewTopK(3, func(a, b pqItem) int { return cmp.Compare(a.Freq, b.Freq) })
+	for _, v := range []pqItem{{"a", 5}, {"b", 1}, {"c", 8}, {"d", 3}, {"e", 10}, {"f", 2}} {
+		tk.Add(v)
+	}
+	assert.Equal(t, 3, tk.Len())
+	assert.Equal(t, []pqItem{{"e", 10}, {"c", 8}, {"a", 5}}, tk.Result())
+	assert.False(t, tk.Add(pqItem{"g", 4}))
+	assert.True(t, tk.Add(pqItem{"h", 6}))
+	var words []string
+	for _, v := range tk.Iterate {
+		words = append(words, v.Word)
+	}
+	assert.Equal(t, []string{"e", "c", "h"}, words)
+}
+
+func TestTopKRandomized(t *testing.T) {
+	tk := NewTopK(10, cmp.Compare[int])
+	data := rand.Perm(1000)
+	for _, v := range data {
+		tk.Add(v)
+	}
+	assert.Equal(t, []int{999, 998, 997, 996, 995, 994, 993, 992, 991, 990}, tk.Result())
+}
+
+func TestTopKZero(t *testing.T) {
+	tk := NewTopK(0, cmp.Compare[int])
+	assert.False(t, tk.Add(1))
+	assert.Equal(t, []int{}, tk.Result())
+	assert.Panics(t, func() {
+		NewTopK(-1, cmp.Compare[int])
+	})
+}
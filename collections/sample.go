@@ -0,0 +1,140 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"iter"
+)
+
+// SliceWeightedSampleWithSource is a variant of SliceWeightedSample
+// using the provided source of randomness.
+func SliceWeightedSampleWithSource[T any](data []T, sampleSize int, weight func(v T) int, rnd RandomSource) []T {
+	tmp := make([]T, len(data))
+	copy(tmp, data)
+	weights := make([]int, len(data))
+	var total, numCandidates int
+	for i, v := range tmp {
+		w := weight(v)
+		if w < 0 {
+			panic("SliceWeightedSample - weights must not be negative")
+		}
+		if w > 0 {
+			numCandidates++
+		}
+		weights[i] = w
+		total += w
+	}
+	if sampleSize > numCandidates {
+		panic("SliceWeightedSample - the sampleSize must be at most the number of items with a positive weight")
+	}
+	for i := 0; i < sampleSize; i++ {
+		last := len(tmp) - 1 - i
+		r := rnd.Intn(total)
+		j := 0
+		for ; r >= weights[j]; j++ {
+			r -= weights[j]
+		}
+		total -= weights[j]
+		tmp[last], tmp[j] = tmp[j], tmp[last]
+		weights[last], weights[j] = weights[j], weights[last]
+	}
+	return tmp[len(tmp)-sampleSize:]
+}
+
+// SliceWeightedSample creates a sample (without replacement) of size
+// given by the sampleSize argument where the probability of an item
+// to be picked is proportional to its weight (typically a frequency)
+// provided by the `weight` function. Items with zero weight are never
+// picked. The function panics in case of a negative weight or in case
+// there are not enough items with a positive weight.
+// Similarly to SliceSample, the function allocates a copy of the input
+// data and the used randomness is not cryptographically secure.
+func SliceWeightedSample[T any](data []T, sampleSize int, weight func(v T) int) []T {
+	return SliceWeightedSampleWithSource(data, sampleSize, weight, newRandomSource())
+}
+
+// reservoir implements the "Algorithm R" for sampling
+// from a stream of unknown length
+type reservoir[T any] struct {
+	items   []T
+	size    int
+	numSeen int
+	rnd     RandomSource
+}
+
+func (res *reservoir[T]) add(v T) {
+	if len(res.items) < res.size {
+		res.items = append(res.items, v)
+
+	} else {
+		j := res.rnd.Intn(res.numSeen + 1)
+		if j < res.size {
+			res.items[j] = v
+		}
+	}
+	res.numSeen++
+}
+
+func newReservoir[T any](size int, rnd RandomSource) *reservoir[T] {
+	if size < 0 {
+		panic("ReservoirSample - the sampleSize must not be negative")
+	}
+	return &reservoir[T]{
+		items: make([]T, 0, size),
+		size:  size,
+		rnd:   rnd,
+	}
+}
+
+// ReservoirSampleWithSource is a variant of ReservoirSample
+// using the provided source of randomness.
+func ReservoirSampleWithSource[T any](data iter.Seq[T], sampleSize int, rnd RandomSource) []T {
+	res := newReservoir[T](sampleSize, rnd)
+	for v := range data {
+		res.add(v)
+	}
+	return res.items
+}
+
+// ReservoirSampleChanWithSource is a variant of ReservoirSampleChan
+// using the provided source of randomness.
+func ReservoirSampleChanWithSource[T any](data <-chan T, sampleSize int, rnd RandomSource) []T {
+	res := newReservoir[T](sampleSize, rnd)
+	for v := range data {
+		res.add(v)
+	}
+	return res.items
+}
+
+// ReservoirSample creates a uniform sample of size given by
+// the sampleSize argument from a sequence of unknown length.
+// The sequence is read just once and only the sample is kept
+// in memory. In case the sequence is shorter than the sampleSize,
+// all its items are returned. The order of the returned items
+// is not guaranteed.
+// Please note that the randomness used by the
+// function is not cryptographically secure.
+func ReservoirSample[T any](data iter.Seq[T], sampleSize int) []T {
+	return ReservoirSampleWithSource(data, sampleSize, newRandomSource())
+}
+
+// ReservoirSampleChan is a variant of ReservoirSample reading
+// values from a channel. The function returns once the channel
+// is closed.
+func ReservoirSampleChan[T any](data <-chan T, sampleSize int) []T {
+	return ReservoirSampleChanWithSource(data, sampleSize, newRandomSource())
+}
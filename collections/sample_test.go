@@ -0,0 +1,132 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type freqItem struct {
+	Word string
+	Freq int
+}
+
+func TestWeightedSample(t *testing.T) {
+	data := []freqItem{{"a", 1}, {"b", 5}, {"c", 0}, {"d", 3}}
+	weight := func(v freqItem) int { return v.Freq }
+	rnd := &mockrnd{sequence: []int{4, 2}}
+	ans := SliceWeightedSampleWithSource(data, 2, weight, rnd)
+	// -) total 9, r=4  a(1), b(5), c(0), d(3) => b
+	// 0) total 4, r=2  a(1), d(3), c(0) | b   => d
+	// 1) -            a, c | d, b
+	assert.Equal(t, []freqItem{{"d", 3}, {"b", 5}}, ans)
+}
+
+func TestWeightedSampleSkipsZeroWeights(t *testing.T) {
+	data := []freqItem{{"a", 0}, {"b", 2}, {"c", 0}, {"d", 1}}
+	weight := func(v freqItem) int { return v.Freq }
+	rnd := &mockrnd{sequence: []int{0, 0}}
+	ans := SliceWeightedSampleWithSource(data, 2, weight, rnd)
+	assert.ElementsMatch(t, []freqItem{{"b", 2}, {"d", 1}}, ans)
+}
+
+func TestWeightedSampleTooBigSample(t *testing.T) {
+	data := []freqItem{{"a", 0}, {"b", 2}, {"c", 0}}
+	rnd := &mockrnd{sequence: []int{0, 0}}
+	assert.Panics(t, func() {
+		SliceWeightedSampleWithSource(data, 2, func(v freqItem) int { return v.Freq }, rnd)
+	})
+}
+
+func TestWeightedSampleNegativeWeight(t *testing.T) {
+	data := []freqItem{{"a", -1}, {"b", 2}}
+	rnd := &mockrnd{sequence: []int{0}}
+	assert.Panics(t, func() {
+		SliceWeightedSampleWithSource(data, 1, func(v freqItem) int { return v.Freq }, rnd)
+	})
+}
+
+func TestWeightedSampleDistribution(t *testing.T) {
+	data := []freqItem{{"a", 1}, {"b", 9}}
+	rnd := rand.New(rand.NewSource(42))
+	var numB int
+	for i := 0; i < 1000; i++ {
+		if SliceWeightedSampleWithSource(data, 1, func(v freqItem) int { return v.Freq }, rnd)[0].Word == "b" {
+			numB++
+		}
+	}
+	assert.InDelta(t, 900, numB, 100)
+}
+
+func TestReservoirSample(t *testing.T) {
+	rnd := &mockrnd{sequence: []int{1, 3, 0}}
+	ans := ReservoirSampleWithSource(slices.Values([]int{10, 11, 12, 13, 14, 15}), 3, rnd)
+	// 10, 11, 12 fill the reservoir
+	// 13: j = 1 % 4 = 1 => [10, 13, 12]
+	// 14: j = 3 % 5 = 3 => skipped
+	// 15: j = 0 % 6 = 0 => [15, 13, 12]
+	assert.Equal(t, []int{15, 13, 12}, ans)
+}
+
+func TestReservoirSampleShortStream(t *testing.T) {
+	rnd := &mockrnd{}
+	ans := ReservoirSampleWithSource(slices.Values([]int{1, 2}), 5, rnd)
+	assert.Equal(t, []int{1, 2}, ans)
+}
+
+func TestReservoirSampleChan(t *testing.T) {
+	ch := make(chan int)
+	go func() {
+		for i := 10; i < 16; i++ {
+			ch <- i
+		}
+		close(ch)
+	}()
+	rnd := &mockrnd{sequence: []int{1, 3, 0}}
+	ans := ReservoirSampleChanWithSource(ch, 3, rnd)
+	assert.Equal(t, []int{15, 13, 12}, ans)
+}
+
+func TestReservoirSampleZeroSize(t *testing.T) {
+	rnd := &mockrnd{sequence: []int{0, 0, 0}}
+	ans := ReservoirSampleWithSource(slices.Values([]int{1, 2, 3}), 0, rnd)
+	assert.Equal(t, []int{}, ans)
+}
+
+func TestSamplesWithSeededSourceAreReproducible(t *testing.T) {
+	data := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	weight := func(v int) int { return v }
+	assert.Equal(
+		t,
+		SliceSampleWithSource(data, 3, rand.New(rand.NewSource(7))),
+		SliceSampleWithSource(data, 3, rand.New(rand.NewSource(7))),
+	)
+	assert.Equal(
+		t,
+		SliceWeightedSampleWithSource(data, 3, weight, rand.New(rand.NewSource(7))),
+		SliceWeightedSampleWithSource(data, 3, weight, rand.New(rand.NewSource(7))),
+	)
+	assert.Equal(
+		t,
+		ReservoirSampleWithSource(slices.Values(data), 3, rand.New(rand.NewSource(7))),
+		ReservoirSampleWithSource(slices.Values(data), 3, rand.New(rand.NewSource(7))),
+	)
+}
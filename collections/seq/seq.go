@@ -0,0 +1,193 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package seq provides lazy combinators over iter.Seq values.
+// As the Iterate methods of the types from the collections package
+// are compatible with iter.Seq/iter.Seq2, they can be used as sources
+// of the pipelines (e.g. `seq.Values(clist.Iterate)` for iter.Seq2).
+// Unless stated otherwise, the functions do not consume the source
+// sequence until the returned sequence is iterated.
+package seq
+
+import (
+	"iter"
+)
+
+// Values converts an iter.Seq2 into iter.Seq of its second values
+// (this is handy for index-value iterators like CircularList.Iterate).
+func Values[K, V any](s iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Map applies `fn` to each item of the sequence
+func Map[T, U any](s iter.Seq[T], fn func(v T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range s {
+			if !yield(fn(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter produces only the items for which `pred` returns true
+func Filter[T any](s iter.Seq[T], pred func(v T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s {
+			if pred(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Take produces at most `n` first items of the sequence.
+// The source sequence is not read beyond the n-th item.
+func Take[T any](s iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		var i int
+		for v := range s {
+			if !yield(v) {
+				return
+			}
+			i++
+			if i >= n {
+				return
+			}
+		}
+	}
+}
+
+// Skip omits `n` first items of the sequence
+func Skip[T any](s iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var i int
+		for v := range s {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk produces slices of `size` consecutive items. The last chunk
+// may be shorter. Each chunk is a newly allocated slice so it is safe
+// to keep it. The function panics if size is less than 1.
+func Chunk[T any](s iter.Seq[T], size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("seq.Chunk: size must be at least 1")
+	}
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, size)
+		for v := range s {
+			chunk = append(chunk, v)
+			if len(chunk) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, size)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Zip produces pairs of items from two sequences. It stops
+// as soon as any of the sequences is exhausted.
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		nextB, stop := iter.Pull(b)
+		defer stop()
+		for va := range a {
+			vb, ok := nextB()
+			if !ok || !yield(va, vb) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate attaches a zero-based index to each item of the sequence
+func Enumerate[T any](s iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		var i int
+		for v := range s {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// FlatMap maps each item to a sequence and produces items
+// of all the sequences one after another.
+func FlatMap[T, U any](s iter.Seq[T], fn func(v T) iter.Seq[U]) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range s {
+			for v2 := range fn(v) {
+				if !yield(v2) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Reduce consumes the whole sequence and folds it into a single value
+func Reduce[T, U any](s iter.Seq[T], reduceFn func(acc U, curr T) U, initial U) U {
+	ans := initial
+	for v := range s {
+		ans = reduceFn(ans, v)
+	}
+	return ans
+}
+
+// Collect consumes the whole sequence and returns its items as a slice
+func Collect[T any](s iter.Seq[T]) []T {
+	ans := make([]T, 0, 10)
+	for v := range s {
+		ans = append(ans, v)
+	}
+	return ans
+}
+
+// GroupBy consumes the whole sequence and groups its items by keys
+// produced by the `key` function. Within a group, the items are kept
+// in the order of the source sequence.
+func GroupBy[T any, K comparable](s iter.Seq[T], key func(v T) K) map[K][]T {
+	ans := make(map[K][]T)
+	for v := range s {
+		k := key(v)
+		ans[k] = append(ans[k], v)
+	}
+	return ans
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seq
+
+import (
+	"iter"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/czcorpus/cnc-gokit/collections"
+	"github.com/stretchr/testify/assert"
+)
+
+// naturals produces an infinite sequence 0, 1, 2, ...
+// and records the number of the produced items
+func naturals(counter *int) func(yield func(int) bool) {
+	return func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			*counter++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestMapFilter(t *testing.T) {
+	s := Filter(
+		Map(slices.Values([]int{1, 2, 3, 4, 5}), func(v int) int { return v * 10 }),
+		func(v int) bool { return v > 20 },
+	)
+	assert.Equal(t, []int{30, 40, 50}, Collect(s))
+}
+
+func TestTakeIsLazy(t *testing.T) {
+	var cnt int
+	s := Take(Map(naturals(&cnt), strconv.Itoa), 3)
+	assert.Equal(t, 0, cnt)
+	assert.Equal(t, []string{"0", "1", "2"}, Collect(s))
+	assert.Equal(t, 3, cnt)
+}
+
+func TestTakeZero(t *testing.T) {
+	var cnt int
+	assert.Equal(t, []int{}, Collect(Take(naturals(&cnt), 0)))
+	assert.Equal(t, 0, cnt)
+}
+
+func TestSkip(t *testing.T) {
+	s := Skip(slices.Values([]int{1, 2, 3, 4, 5}), 2)
+	assert.Equal(t, []int{3, 4, 5}, Collect(s))
+	s = Skip(slices.Values([]int{1, 2}), 5)
+	assert.Equal(t, []int{}, Collect(s))
+}
+
+func TestChunk(t *testing.T) {
+	s := Chunk(slices.Values([]int{1, 2, 3, 4, 5}), 2)
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, Collect(s))
+	assert.Panics(t, func() {
+		Chunk(slices.Values([]int{1}), 0)
+	})
+}
+
+func TestZip(t *testing.T) {
+	var cnt int
+	ans := make(map[string]int)
+	for k, v := range Zip(slices.Values([]string{"a", "b", "c"}), naturals(&cnt)) {
+		ans[k] = v
+	}
+	assert.Equal(t, map[string]int{"a": 0, "b": 1, "c": 2}, ans)
+}
+
+func TestEnumerate(t *testing.T) {
+	var idxs []int
+	var vals []string
+	for i, v := range Enumerate(slices.Values([]string{"x", "y"})) {
+		idxs = append(idxs, i)
+		vals = append(vals, v)
+	}
+	assert.Equal(t, []int{0, 1}, idxs)
+	assert.Equal(t, []string{"x", "y"}, vals)
+}
+
+func TestFlatMap(t *testing.T) {
+	s := FlatMap(slices.Values([]int{1, 2, 3}), func(v int) iter.Seq[int] {
+		return slices.Values(slices.Repeat([]int{v}, v))
+	})
+	assert.Equal(t, []int{1, 2, 2}, Collect(Take(s, 3)))
+	assert.Equal(t, []int{1, 2, 2, 3, 3, 3}, Collect(s))
+}
+
+func TestReduce(t *testing.T) {
+	ans := Reduce(
+		slices.Values([]int{1, 2, 3, 4}),
+		func(acc int, curr int) int { return acc + curr },
+		70,
+	)
+	assert.Equal(t, 80, ans)
+}
+
+func TestGroupBy(t *testing.T) {
+	ans := GroupBy(
+		slices.Values([]string{"foo", "bar", "fiz", "baz", "xyz"}),
+		func(v string) byte { return v[0] },
+	)
+	assert.Equal(
+		t,
+		map[byte][]string{'f': {"foo", "fiz"}, 'b': {"bar", "baz"}, 'x': {"xyz"}},
+		ans,
+	)
+}
+
+func TestWithCollections(t *testing.T) {
+	lst := collections.NewCircularList[int](5)
+	for i := 0; i < 7; i++ {
+		lst.Append(i)
+	}
+	ans := Collect(Filter(Values(lst.Iterate), func(v int) bool { return v%2 == 0 }))
+	assert.Equal(t, []int{2, 4, 6}, ans)
+}
@@ -18,6 +18,7 @@ package collections
 
 import (
 	"cmp"
+	"encoding/json"
 	"sort"
 )
 
@@ -85,7 +86,7 @@ func (set *Set[T]) Iterate(yield func(item T) bool) {
 	}
 }
 
-func (set *Set[T]) Union(other Set[T]) *Set[T] {
+func (set *Set[T]) Union(other *Set[T]) *Set[T] {
 	set.testAndInit()
 	ans := NewSet(set.ToSlice()...)
 	other.ForEach(func(item T) {
@@ -118,6 +119,103 @@ func (set *Set[T]) Intersect(other *Set[T]) *Set[T] {
 	return ans
 }
 
+// SymmetricDifference returns a new set containing items
+// present in exactly one of the two sets.
+func (set *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	set.testAndInit()
+	ans := set.Sub(other)
+	other.ForEach(func(item T) {
+		if !set.Contains(item) {
+			ans.Add(item)
+		}
+	})
+	return ans
+}
+
+// IsSubset tests whether all the items of the set are
+// also contained in the `other` set.
+func (set *Set[T]) IsSubset(other *Set[T]) bool {
+	if set.Size() > other.Size() {
+		return false
+	}
+	for item := range set.Iterate {
+		if !other.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset tests whether the set contains all the items
+// of the `other` set.
+func (set *Set[T]) IsSuperset(other *Set[T]) bool {
+	return other.IsSubset(set)
+}
+
+// Equal tests whether both sets contain the same items
+func (set *Set[T]) Equal(other *Set[T]) bool {
+	return set.Size() == other.Size() && set.IsSubset(other)
+}
+
+// Disjoint tests whether the sets have no items in common
+func (set *Set[T]) Disjoint(other *Set[T]) bool {
+	smaller, larger := set, other
+	if smaller.Size() > larger.Size() {
+		smaller, larger = larger, smaller
+	}
+	for item := range smaller.Iterate {
+		if larger.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON encodes the set as a JSON array
+// with items in a stable order (see ToOrderedSlice).
+// The value receiver makes sure the method is used also
+// for sets stored as value fields of marshaled structs.
+func (set Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.ToOrderedSlice())
+}
+
+// UnmarshalJSON decodes the set from a JSON array.
+// Possible duplicate items are merged.
+func (set *Set[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	*set = *NewSet(items...)
+	return nil
+}
+
+// SetUnion creates a new set containing items of all
+// the provided sets.
+func SetUnion[T cmp.Ordered](sets ...*Set[T]) *Set[T] {
+	ans := NewSet[T]()
+	for _, s := range sets {
+		s.ForEach(func(item T) {
+			ans.Add(item)
+		})
+	}
+	return ans
+}
+
+// SetIntersection creates a new set containing items
+// present in all the provided sets. For no sets, an empty
+// set is returned.
+func SetIntersection[T cmp.Ordered](sets ...*Set[T]) *Set[T] {
+	if len(sets) == 0 {
+		return NewSet[T]()
+	}
+	ans := NewSet(sets[0].ToSlice()...)
+	for _, s := range sets[1:] {
+		ans = ans.Intersect(s)
+	}
+	return ans
+}
+
 func NewSet[T cmp.Ordered](values ...T) *Set[T] {
 	ans := Set[T]{data: make(map[T]bool)}
 	for _, v := range values {
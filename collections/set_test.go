@@ -17,6 +17,7 @@
 package collections
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -158,3 +159,88 @@ func TestSetIntersect(t *testing.T) {
 	assert.True(t, s12.Contains("3"))
 	assert.Equal(t, 2, s12.Size())
 }
+
+func TestSetUnion(t *testing.T) {
+	s1 := NewSet("1", "2")
+	s2 := NewSet("2", "3")
+	assert.Equal(t, []string{"1", "2", "3"}, s1.Union(s2).ToOrderedSlice())
+	assert.Equal(t, 2, s1.Size())
+}
+
+func TestSetSymmetricDifference(t *testing.T) {
+	s1 := NewSet("1", "2", "3")
+	s2 := NewSet("2", "3", "4")
+	assert.Equal(t, []string{"1", "4"}, s1.SymmetricDifference(s2).ToOrderedSlice())
+	s0 := Set[string]{}
+	assert.Equal(t, []string{"1", "2", "3"}, s0.SymmetricDifference(s1).ToOrderedSlice())
+}
+
+func TestSetSubsetSuperset(t *testing.T) {
+	s1 := NewSet(1, 2)
+	s2 := NewSet(1, 2, 3)
+	s0 := Set[int]{}
+	assert.True(t, s1.IsSubset(s2))
+	assert.False(t, s2.IsSubset(s1))
+	assert.True(t, s2.IsSuperset(s1))
+	assert.False(t, s1.IsSuperset(s2))
+	assert.True(t, s0.IsSubset(s1))
+	assert.True(t, s1.IsSubset(s1))
+}
+
+func TestSetEqual(t *testing.T) {
+	assert.True(t, NewSet(1, 2, 3).Equal(NewSet(3, 2, 1)))
+	assert.False(t, NewSet(1, 2, 3).Equal(NewSet(1, 2)))
+	assert.False(t, NewSet(1, 2, 4).Equal(NewSet(1, 2, 3)))
+	assert.True(t, NewSet[int]().Equal(&Set[int]{}))
+}
+
+func TestSetDisjoint(t *testing.T) {
+	assert.True(t, NewSet(1, 2).Disjoint(NewSet(3, 4, 5)))
+	assert.False(t, NewSet(1, 2).Disjoint(NewSet(2, 4, 5)))
+	assert.True(t, NewSet(1, 2).Disjoint(NewSet[int]()))
+}
+
+func TestSetMultiUnionIntersection(t *testing.T) {
+	s1 := NewSet(1, 2, 3)
+	s2 := NewSet(2, 3, 4)
+	s3 := NewSet(3, 4, 5)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, SetUnion(s1, s2, s3).ToOrderedSlice())
+	assert.Equal(t, []int{3}, SetIntersection(s1, s2, s3).ToOrderedSlice())
+	assert.Equal(t, 0, SetUnion[int]().Size())
+	assert.Equal(t, 0, SetIntersection[int]().Size())
+}
+
+func TestSetJSON(t *testing.T) {
+	s := NewSet("foo", "bar", "baz")
+	data, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, `["bar","baz","foo"]`, string(data))
+
+	var s2 Set[string]
+	err = json.Unmarshal([]byte(`["x", "y", "x"]`), &s2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"x", "y"}, s2.ToOrderedSlice())
+
+	type conf struct {
+		Tags *Set[string] `json:"tags"`
+	}
+	var c conf
+	err = json.Unmarshal([]byte(`{"tags": ["a", "b"]}`), &c)
+	assert.NoError(t, err)
+	assert.True(t, c.Tags.Contains("a"))
+}
+
+func TestSetJSONValueField(t *testing.T) {
+	type conf struct {
+		Tags Set[string] `json:"tags"`
+	}
+	c := conf{Tags: *NewSet("b", "a")}
+	data, err := json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"tags":["a","b"]}`, string(data))
+
+	var c2 conf
+	err = json.Unmarshal(data, &c2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, c2.Tags.ToOrderedSlice())
+}
@@ -0,0 +1,349 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"encoding/json"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+const (
+	DefaultNumMapShards = 32
+)
+
+// ShardedConcurrentMap is a concurrency-safe map split into
+// a number of independent shards (each being a ConcurrentMap) based
+// on a hash of a key. This means that operations on keys from different
+// shards do not block each other which makes the map suitable for
+// heavy concurrent write load.
+//
+// String, integer and float keys are hashed directly, keys of other
+// types (named types, structs, arrays, pointers etc.) are hashed
+// by walking their value via reflection in a way consistent with
+// the == operator (e.g. -0 and +0 floats inside a struct produce
+// the same hash).
+type ShardedConcurrentMap[K comparable, T any] struct {
+	shards []*ConcurrentMap[K, T]
+	seed   maphash.Seed
+}
+
+// mix64 is a splitmix64 finalizer used to spread integer keys
+func mix64(v uint64) uint64 {
+	v ^= v >> 30
+	v *= 0xbf58476d1ce4e5b9
+	v ^= v >> 27
+	v *= 0x94d049bb133111eb
+	v ^= v >> 31
+	return v
+}
+
+func (cm *ShardedConcurrentMap[K, T]) hashKey(k K) uint64 {
+	switch tk := any(k).(type) {
+	case string:
+		return maphash.String(cm.seed, tk)
+	case int:
+		return mix64(uint64(tk))
+	case int8:
+		return mix64(uint64(tk))
+	case int16:
+		return mix64(uint64(tk))
+	case int32:
+		return mix64(uint64(tk))
+	case int64:
+		return mix64(uint64(tk))
+	case uint:
+		return mix64(uint64(tk))
+	case uint8:
+		return mix64(uint64(tk))
+	case uint16:
+		return mix64(uint64(tk))
+	case uint32:
+		return mix64(uint64(tk))
+	case uint64:
+		return mix64(tk)
+	case uintptr:
+		return mix64(uint64(tk))
+	case float32:
+		return hashFloat(float64(tk))
+	case float64:
+		return hashFloat(tk)
+	default:
+		return cm.hashValue(reflect.ValueOf(k))
+	}
+}
+
+func hashFloat(v float64) uint64 {
+	if v == 0 { // make sure -0 and +0 go to the same shard
+		return 0
+	}
+	return mix64(math.Float64bits(v))
+}
+
+// hashValue hashes a value of any comparable type so that values
+// equal in terms of the == operator produce the same hash.
+func (cm *ShardedConcurrentMap[K, T]) hashValue(v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.String:
+		return maphash.String(cm.seed, v.String())
+	case reflect.Bool:
+		if v.Bool() {
+			return 1
+		}
+		return 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return mix64(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return mix64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return hashFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		return mix64(hashFloat(real(c))*31 + hashFloat(imag(c)))
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return mix64(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		return cm.hashValue(v.Elem())
+	case reflect.Array:
+		var ans uint64
+		for i := 0; i < v.Len(); i++ {
+			ans = mix64(ans*31 + cm.hashValue(v.Index(i)))
+		}
+		return ans
+	case reflect.Struct:
+		var ans uint64
+		for i := 0; i < v.NumField(); i++ {
+			ans = mix64(ans*31 + cm.hashValue(v.Field(i)))
+		}
+		return ans
+	default:
+		// other kinds are not comparable (and cannot be used as map keys)
+		return 0
+	}
+}
+
+func (cm *ShardedConcurrentMap[K, T]) shard(k K) *ConcurrentMap[K, T] {
+	return cm.shards[cm.hashKey(k)%uint64(len(cm.shards))]
+}
+
+func (cm *ShardedConcurrentMap[K, T]) Get(k K) T {
+	return cm.shard(k).Get(k)
+}
+
+func (cm *ShardedConcurrentMap[K, T]) GetWithTest(k K) (T, bool) {
+	return cm.shard(k).GetWithTest(k)
+}
+
+func (cm *ShardedConcurrentMap[K, T]) HasKey(k K) bool {
+	return cm.shard(k).HasKey(k)
+}
+
+func (cm *ShardedConcurrentMap[K, T]) Set(k K, v T) {
+	cm.shard(k).Set(k, v)
+}
+
+func (cm *ShardedConcurrentMap[K, T]) Delete(k K) {
+	cm.shard(k).Delete(k)
+}
+
+// GetOrSet - see ConcurrentMap.GetOrSet
+func (cm *ShardedConcurrentMap[K, T]) GetOrSet(k K, v T) (T, bool) {
+	return cm.shard(k).GetOrSet(k, v)
+}
+
+// GetOrCompute - see ConcurrentMap.GetOrCompute
+func (cm *ShardedConcurrentMap[K, T]) GetOrCompute(k K, fn func() T) (T, bool) {
+	return cm.shard(k).GetOrCompute(k, fn)
+}
+
+// Compute - see ConcurrentMap.Compute
+func (cm *ShardedConcurrentMap[K, T]) Compute(k K, fn func(old T, exists bool) (T, bool)) (T, bool) {
+	return cm.shard(k).Compute(k, fn)
+}
+
+// CompareAndSwap - see ConcurrentMap.CompareAndSwap
+func (cm *ShardedConcurrentMap[K, T]) CompareAndSwap(k K, old, newVal T) bool {
+	return cm.shard(k).CompareAndSwap(k, old, newVal)
+}
+
+// LoadAndDelete - see ConcurrentMap.LoadAndDelete
+func (cm *ShardedConcurrentMap[K, T]) LoadAndDelete(k K) (T, bool) {
+	return cm.shard(k).LoadAndDelete(k)
+}
+
+// Iterate goes through all the values in the map and calls
+// the yield function on them. It operates on snapshots of individual
+// shards taken just before each shard is iterated, so it does not
+// hold any lock during iteration. But please note that the result
+// is not a consistent snapshot of the whole map.
+func (cm *ShardedConcurrentMap[K, T]) Iterate(yield func(k K, v T) bool) {
+	for _, shard := range cm.shards {
+		stop := false
+		shard.Iterate(func(k K, v T) bool {
+			if !yield(k, v) {
+				stop = true
+				return false
+			}
+			return true
+		})
+		if stop {
+			return
+		}
+	}
+}
+
+// Find returns the first key-value pair matching pred. The third return value
+// indicates whether a match was found. Holds a read lock of each searched
+// shard — callers must not acquire a write lock from within pred.
+func (cm *ShardedConcurrentMap[K, T]) Find(pred func(K, T) bool) (K, T, bool) {
+	for _, shard := range cm.shards {
+		if k, v, ok := shard.Find(pred); ok {
+			return k, v, true
+		}
+	}
+	var zeroK K
+	var zeroV T
+	return zeroK, zeroV, false
+}
+
+// Any reports whether at least one entry satisfies pred. Holds a read lock
+// of each searched shard — callers must not acquire a write lock from within pred.
+func (cm *ShardedConcurrentMap[K, T]) Any(pred func(K, T) bool) bool {
+	for _, shard := range cm.shards {
+		if shard.Any(pred) {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the number of entries satisfying pred. Holds a read lock
+// of each searched shard — callers must not acquire a write lock from within pred.
+func (cm *ShardedConcurrentMap[K, T]) Count(pred func(K, T) bool) int {
+	var ans int
+	for _, shard := range cm.shards {
+		ans += shard.Count(pred)
+	}
+	return ans
+}
+
+// Update applies fn to all the values in the map. Shards
+// are updated one by one, each under its own write lock.
+func (cm *ShardedConcurrentMap[K, T]) Update(fn func(k K, v T) T) {
+	for _, shard := range cm.shards {
+		shard.Update(fn)
+	}
+}
+
+func (cm *ShardedConcurrentMap[K, T]) Keys() []K {
+	ans := make([]K, 0, cm.Len())
+	for _, shard := range cm.shards {
+		ans = append(ans, shard.Keys()...)
+	}
+	return ans
+}
+
+func (cm *ShardedConcurrentMap[K, T]) Values() []T {
+	ans := make([]T, 0, cm.Len())
+	for _, shard := range cm.shards {
+		ans = append(ans, shard.Values()...)
+	}
+	return ans
+}
+
+// AsMap creates a shallow copy of all the data stored
+// in the map
+func (cm *ShardedConcurrentMap[K, T]) AsMap() map[K]T {
+	ans := make(map[K]T)
+	for _, shard := range cm.shards {
+		shard.RLock()
+		for k, v := range shard.data {
+			ans[k] = v
+		}
+		shard.RUnlock()
+	}
+	return ans
+}
+
+// Len returns number of key-value pairs stored in the map
+func (cm *ShardedConcurrentMap[K, T]) Len() int {
+	var ans int
+	for _, shard := range cm.shards {
+		ans += shard.Len()
+	}
+	return ans
+}
+
+// NumShards returns number of independent shards of the map
+func (cm *ShardedConcurrentMap[K, T]) NumShards() int {
+	return len(cm.shards)
+}
+
+func (cm *ShardedConcurrentMap[K, T]) Filter(fn func(k K, v T) bool) *ShardedConcurrentMap[K, T] {
+	ans := &ShardedConcurrentMap[K, T]{
+		shards: make([]*ConcurrentMap[K, T], len(cm.shards)),
+		seed:   cm.seed,
+	}
+	for i, shard := range cm.shards {
+		ans.shards[i] = shard.Filter(fn)
+	}
+	return ans
+}
+
+func (cm *ShardedConcurrentMap[K, T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cm.AsMap())
+}
+
+// NewShardedConcurrentMap creates a new empty map with the
+// specified number of shards. In case numShards is not
+// a positive number, DefaultNumMapShards is used.
+func NewShardedConcurrentMap[K comparable, T any](numShards int) *ShardedConcurrentMap[K, T] {
+	if numShards <= 0 {
+		numShards = DefaultNumMapShards
+	}
+	ans := &ShardedConcurrentMap[K, T]{
+		shards: make([]*ConcurrentMap[K, T], numShards),
+		seed:   maphash.MakeSeed(),
+	}
+	for i := range ans.shards {
+		ans.shards[i] = NewConcurrentMap[K, T]()
+	}
+	return ans
+}
+
+// NewShardedConcurrentMapFrom creates a new map with the specified
+// number of shards and fills it with a copy of the provided data.
+func NewShardedConcurrentMapFrom[K comparable, T any](numShards int, data map[K]T) *ShardedConcurrentMap[K, T] {
+	ans := NewShardedConcurrentMap[K, T](numShards)
+	for k, v := range data {
+		ans.shard(k).data[k] = v
+	}
+	return ans
+}
+
+func NewShardedConcurrentMapFromJSON[K comparable, T any](numShards int, data []byte) (*ShardedConcurrentMap[K, T], error) {
+	data2 := make(map[K]T)
+	err := json.Unmarshal(data, &data2)
+	if err != nil {
+		return nil, err
+	}
+	return NewShardedConcurrentMapFrom(numShards, data2), nil
+}
@@ -0,0 +1,289 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardedConcurrentMapSetGet(t *testing.T) {
+	c := NewShardedConcurrentMap[string, int](4)
+	c.Set("foo", 100)
+	c.Set("bar", 200)
+	assert.Equal(t, 100, c.Get("foo"))
+	assert.Equal(t, 200, c.Get("bar"))
+	v, ok := c.GetWithTest("baz")
+	assert.Equal(t, 0, v)
+	assert.False(t, ok)
+	assert.True(t, c.HasKey("bar"))
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestShardedConcurrentMapDefaultShards(t *testing.T) {
+	c := NewShardedConcurrentMap[int, int](0)
+	assert.Equal(t, DefaultNumMapShards, c.NumShards())
+}
+
+func TestShardedConcurrentMapNonPrimitiveKeys(t *testing.T) {
+	c := NewShardedConcurrentMap[cmapItem, float64](8)
+	c.Set(cmapItem{"foo", 1}, 0.5)
+	c.Set(cmapItem{"foo", 2}, 0.7)
+	assert.Equal(t, 0.5, c.Get(cmapItem{"foo", 1}))
+	assert.Equal(t, 0.7, c.Get(cmapItem{"foo", 2}))
+	c2 := NewShardedConcurrentMap[float64, int](8)
+	c2.Set(0.0, 1)
+	assert.Equal(t, 1, c2.Get(math.Copysign(0, -1)))
+}
+
+type shardedMapLabel string
+
+func TestShardedConcurrentMapKeysConsistentWithEquality(t *testing.T) {
+	type floatKey struct {
+		X float64
+	}
+	c := NewShardedConcurrentMap[floatKey, int](32)
+	for i := 0; i < 32; i++ {
+		c.Set(floatKey{0}, i)
+		c.Set(floatKey{math.Copysign(0, -1)}, i)
+	}
+	assert.Equal(t, 1, c.Len())
+
+	type arrKey [2]float32
+	c2 := NewShardedConcurrentMap[arrKey, int](32)
+	c2.Set(arrKey{0, 1}, 1)
+	c2.Set(arrKey{float32(math.Copysign(0, -1)), 1}, 2)
+	assert.Equal(t, 1, c2.Len())
+	assert.Equal(t, 2, c2.Get(arrKey{0, 1}))
+
+	c3 := NewShardedConcurrentMap[any, int](32)
+	c3.Set(shardedMapLabel("foo"), 1)
+	c3.Set(shardedMapLabel("foo"), 2)
+	c3.Set("foo", 3) // different dynamic type => different key
+	var ptr *int
+	c3.Set(ptr, 4)
+	c3.Set(nil, 5)
+	assert.Equal(t, 4, c3.Len())
+	assert.Equal(t, 2, c3.Get(shardedMapLabel("foo")))
+	assert.Equal(t, 3, c3.Get("foo"))
+	assert.Equal(t, 4, c3.Get(ptr))
+	assert.Equal(t, 5, c3.Get(nil))
+}
+
+func TestShardedConcurrentMapDelete(t *testing.T) {
+	c := NewShardedConcurrentMapFrom(4, map[string]int{"foo": 1, "bar": 2, "baz": 3})
+	c.Delete("bar")
+	assert.False(t, c.HasKey("bar"))
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestShardedConcurrentMapKeysValues(t *testing.T) {
+	c := NewShardedConcurrentMapFrom(4, map[string]int{"foo": 1, "bar": 2, "baz": 3})
+	assert.ElementsMatch(t, []string{"foo", "bar", "baz"}, c.Keys())
+	assert.ElementsMatch(t, []int{1, 2, 3}, c.Values())
+	assert.Equal(t, map[string]int{"foo": 1, "bar": 2, "baz": 3}, c.AsMap())
+}
+
+func TestShardedConcurrentMapUpdate(t *testing.T) {
+	c := NewShardedConcurrentMapFrom(4, map[string]int{"foo": 1, "bar": 2, "baz": 3})
+	c.Update(func(k string, v int) int {
+		return v + 1
+	})
+	for i, v := range []string{"foo", "bar", "baz"} {
+		assert.Equal(t, i+2, c.Get(v))
+	}
+}
+
+func TestShardedConcurrentMapIterate(t *testing.T) {
+	c := NewShardedConcurrentMapFrom(4, map[string]int{
+		"foo": 1, "bar": 2, "baz": 3, "faz": 4, "fuz": 5,
+	})
+	itemTest := make([]cmapItem, 0, 5)
+	for k, v := range c.Iterate {
+		itemTest = append(itemTest, cmapItem{K: k, V: v})
+	}
+	sort.Slice(itemTest, func(i, j int) bool {
+		return itemTest[i].V < itemTest[j].V
+	})
+	assert.Equal(
+		t,
+		[]cmapItem{{"foo", 1}, {"bar", 2}, {"baz", 3}, {"faz", 4}, {"fuz", 5}},
+		itemTest,
+	)
+	var cnt int
+	for range c.Iterate {
+		cnt++
+		if cnt == 2 {
+			break
+		}
+	}
+	assert.Equal(t, 2, cnt)
+}
+
+func TestShardedConcurrentMapFilter(t *testing.T) {
+	c := NewShardedConcurrentMapFrom(4, map[string]int{
+		"foo": 1, "bar": 2, "baz": 3, "faz": 4, "fuz": 5,
+	})
+	c = c.Filter(func(k string, v int) bool {
+		return k[0] == 'f'
+	})
+	assert.Equal(t, 1, c.Get("foo"))
+	assert.Equal(t, 4, c.Get("faz"))
+	assert.Equal(t, 5, c.Get("fuz"))
+	assert.Equal(t, 3, c.Len())
+}
+
+func TestShardedConcurrentMapFindAnyCount(t *testing.T) {
+	c := NewShardedConcurrentMapFrom(4, map[string]int{"foo": 1, "bar": 2, "baz": 3, "faz": 4})
+	k, v, ok := c.Find(func(k string, v int) bool { return v == 3 })
+	assert.True(t, ok)
+	assert.Equal(t, "baz", k)
+	assert.Equal(t, 3, v)
+	assert.True(t, c.Any(func(k string, v int) bool { return v > 3 }))
+	assert.Equal(t, 2, c.Count(func(k string, v int) bool { return v%2 == 0 }))
+}
+
+func TestShardedConcurrentMapJSON(t *testing.T) {
+	c, err := NewShardedConcurrentMapFromJSON[string, int](4, []byte(`{"foo": 10, "bar": 20}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 10, c.Get("foo"))
+	src, err := json.Marshal(c)
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(src), `"bar":20`))
+	assert.True(t, strings.Contains(string(src), `"foo":10`))
+}
+
+func TestShardedConcurrentMapConcurrentWrites(t *testing.T) {
+	c := NewShardedConcurrentMap[int, int](8)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(offs int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				c.Set(offs*1000+j, j)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 8000, c.Len())
+}
+
+func TestShardedConcurrentMapAtomicOps(t *testing.T) {
+	c := NewShardedConcurrentMap[string, int](4)
+	v, loaded := c.GetOrSet("foo", 10)
+	assert.Equal(t, 10, v)
+	assert.False(t, loaded)
+	v, loaded = c.GetOrCompute("foo", func() int { return 20 })
+	assert.Equal(t, 10, v)
+	assert.True(t, loaded)
+	v, ok := c.Compute("foo", func(old int, exists bool) (int, bool) { return old * 2, true })
+	assert.Equal(t, 20, v)
+	assert.True(t, ok)
+	assert.True(t, c.CompareAndSwap("foo", 20, 30))
+	v, ok = c.LoadAndDelete("foo")
+	assert.Equal(t, 30, v)
+	assert.True(t, ok)
+	assert.Equal(t, 0, c.Len())
+}
+
+// ------------------ benchmarks --------------------------
+
+const benchNumKeys = 4096
+
+func benchKeys() []string {
+	ans := make([]string, benchNumKeys)
+	for i := range ans {
+		ans[i] = fmt.Sprintf("key-%d", i)
+	}
+	return ans
+}
+
+type benchMap interface {
+	Get(k string) int
+	Set(k string, v int)
+}
+
+type syncMapAdapter struct {
+	data sync.Map
+}
+
+func (m *syncMapAdapter) Get(k string) int {
+	v, ok := m.data.Load(k)
+	if !ok {
+		return 0
+	}
+	return v.(int)
+}
+
+func (m *syncMapAdapter) Set(k string, v int) {
+	m.data.Store(k, v)
+}
+
+// runMapBenchmark runs parallel load where each `writeEvery`-th
+// operation is a write and the rest are reads
+func runMapBenchmark(b *testing.B, m benchMap, writeEvery int) {
+	keys := benchKeys()
+	for i, k := range keys {
+		m.Set(k, i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		var i int
+		for pb.Next() {
+			k := keys[i%benchNumKeys]
+			if i%writeEvery == 0 {
+				m.Set(k, i)
+
+			} else {
+				m.Get(k)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkMapsWriteHeavy(b *testing.B) {
+	b.Run("ConcurrentMap", func(b *testing.B) {
+		runMapBenchmark(b, NewConcurrentMap[string, int](), 2)
+	})
+	b.Run("ShardedConcurrentMap", func(b *testing.B) {
+		runMapBenchmark(b, NewShardedConcurrentMap[string, int](DefaultNumMapShards), 2)
+	})
+	b.Run("sync.Map", func(b *testing.B) {
+		runMapBenchmark(b, &syncMapAdapter{}, 2)
+	})
+}
+
+func BenchmarkMapsReadHeavy(b *testing.B) {
+	b.Run("ConcurrentMap", func(b *testing.B) {
+		runMapBenchmark(b, NewConcurrentMap[string, int](), 20)
+	})
+	b.Run("ShardedConcurrentMap", func(b *testing.B) {
+		runMapBenchmark(b, NewShardedConcurrentMap[string, int](DefaultNumMapShards), 20)
+	})
+	b.Run("sync.Map", func(b *testing.B) {
+		runMapBenchmark(b, &syncMapAdapter{}, 20)
+	})
+}
@@ -73,11 +73,22 @@ func SliceShuffle[T any](data []T) {
 	}
 }
 
-type randomSource interface {
+// RandomSource is a source of randomness used by the sampling
+// functions. It is satisfied e.g. by *rand.Rand from math/rand
+// so a seeded source can be used to obtain reproducible samples.
+type RandomSource interface {
 	Intn(n int) int
 }
 
-func sliceSample[T any](data []T, sampleSize int, rnd randomSource) []T {
+// newRandomSource creates a source of randomness used by
+// the sampling functions in case no source is provided
+func newRandomSource() RandomSource {
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+// SliceSampleWithSource is a variant of SliceSample using
+// the provided source of randomness.
+func SliceSampleWithSource[T any](data []T, sampleSize int, rnd RandomSource) []T {
 	if sampleSize > len(data) {
 		panic("SliceSample - the sampleSize must be at most the length of the original data")
 	}
@@ -98,14 +109,15 @@ func sliceSample[T any](data []T, sampleSize int, rnd randomSource) []T {
 // function is not cryptographically secure.
 // A zero-size sample is accepted.
 func SliceSample[T any](data []T, sampleSize int) []T {
-	return sliceSample(data, sampleSize, rand.New(rand.NewSource(time.Now().Unix())))
+	return SliceSampleWithSource(data, sampleSize, newRandomSource())
 }
 
 // SliceGroupBy takse a slice and groups its items based on
 // how function `key` associates string values to each individual
 // item.
 // The order of groups is not guaranteed (it comes from how
-// internally used map works).
+// internally used map works). For typed keys and a stable
+// order of groups, see SliceGroupByKey.
 func SliceGroupBy[T any](items []T, key func(v T) string) [][]T {
 	tmp := make(map[string][]T)
 	numGroups := 0
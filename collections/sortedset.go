@@ -0,0 +1,245 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"cmp"
+	"encoding/json"
+	"iter"
+)
+
+// SortedSet is a set implementation for ordered value types
+// which keeps its items sorted (using a balanced BinTreeFunc).
+// It shares method names with Set so it can be used as its
+// replacement in case ordered access is needed often.
+// Add, Remove and Contains are O(log n).
+type SortedSet[T cmp.Ordered] struct {
+	tree *BinTreeFunc[T]
+}
+
+func (set *SortedSet[T]) testAndInit() {
+	if set.tree == nil {
+		set.tree = NewOrderedBinTree[T]()
+		set.tree.UniqValues = true
+	}
+}
+
+func (set *SortedSet[T]) Add(value T) {
+	set.testAndInit()
+	set.tree.Add(value)
+}
+
+func (set *SortedSet[T]) Remove(value T) {
+	set.testAndInit()
+	idx := set.tree.Rank(value)
+	if idx < set.tree.Len() && cmp.Compare(set.tree.Get(idx), value) == 0 { // note: unlike ==, this works also for NaN
+		set.tree.Remove(idx)
+	}
+}
+
+func (set *SortedSet[T]) Contains(value T) bool {
+	set.testAndInit()
+	_, ok := set.tree.Find(value)
+	return ok
+}
+
+func (set *SortedSet[T]) Size() int {
+	set.testAndInit()
+	return set.tree.Len()
+}
+
+// ToSlice returns all the items in ascending order
+func (set *SortedSet[T]) ToSlice() []T {
+	set.testAndInit()
+	return set.tree.ToSlice()
+}
+
+// ToOrderedSlice is an alias for ToSlice as the items
+// are always ordered.
+func (set *SortedSet[T]) ToOrderedSlice() []T {
+	return set.ToSlice()
+}
+
+func (set *SortedSet[T]) ForEach(fn func(item T)) {
+	set.testAndInit()
+	for _, v := range set.tree.Iterate {
+		fn(v)
+	}
+}
+
+// Iterate supports "range" form iteration through
+// all the values of the set in ascending order.
+func (set *SortedSet[T]) Iterate(yield func(item T) bool) {
+	set.testAndInit()
+	for _, v := range set.tree.Iterate {
+		if !yield(v) {
+			return
+		}
+	}
+}
+
+// Range returns an iterator over items `v` for which
+// lo <= v <= hi holds (in ascending order).
+func (set *SortedSet[T]) Range(lo, hi T) iter.Seq[T] {
+	set.testAndInit()
+	return func(yield func(item T) bool) {
+		for _, v := range set.tree.RangeIter(lo, hi) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Rank returns number of items lesser than `value`
+func (set *SortedSet[T]) Rank(value T) int {
+	set.testAndInit()
+	return set.tree.Rank(value)
+}
+
+// Min returns the lowest item of the set. For an empty set,
+// false is returned as the second value.
+func (set *SortedSet[T]) Min() (T, bool) {
+	set.testAndInit()
+	if set.tree.Len() == 0 {
+		var zeroVal T
+		return zeroVal, false
+	}
+	return set.tree.Get(0), true
+}
+
+// Max returns the greatest item of the set. For an empty set,
+// false is returned as the second value.
+func (set *SortedSet[T]) Max() (T, bool) {
+	set.testAndInit()
+	if set.tree.Len() == 0 {
+		var zeroVal T
+		return zeroVal, false
+	}
+	return set.tree.Get(-1), true
+}
+
+// PopMin removes the lowest item from the set and returns it.
+// For an empty set, false is returned as the second value.
+func (set *SortedSet[T]) PopMin() (T, bool) {
+	set.testAndInit()
+	if set.tree.Len() == 0 {
+		var zeroVal T
+		return zeroVal, false
+	}
+	return set.tree.Remove(0), true
+}
+
+func (set *SortedSet[T]) Union(other *SortedSet[T]) *SortedSet[T] {
+	ans := NewSortedSet(set.ToSlice()...)
+	other.ForEach(func(item T) {
+		ans.Add(item)
+	})
+	return ans
+}
+
+func (set *SortedSet[T]) Sub(other *SortedSet[T]) *SortedSet[T] {
+	ans := NewSortedSet[T]()
+	set.ForEach(func(item T) {
+		if !other.Contains(item) {
+			ans.Add(item)
+		}
+	})
+	return ans
+}
+
+func (set *SortedSet[T]) Intersect(other *SortedSet[T]) *SortedSet[T] {
+	ans := NewSortedSet[T]()
+	set.ForEach(func(item T) {
+		if other.Contains(item) {
+			ans.Add(item)
+		}
+	})
+	return ans
+}
+
+// SymmetricDifference returns a new set containing items
+// present in exactly one of the two sets.
+func (set *SortedSet[T]) SymmetricDifference(other *SortedSet[T]) *SortedSet[T] {
+	ans := set.Sub(other)
+	other.ForEach(func(item T) {
+		if !set.Contains(item) {
+			ans.Add(item)
+		}
+	})
+	return ans
+}
+
+// IsSubset tests whether all the items of the set are
+// also contained in the `other` set.
+func (set *SortedSet[T]) IsSubset(other *SortedSet[T]) bool {
+	if set.Size() > other.Size() {
+		return false
+	}
+	for item := range set.Iterate {
+		if !other.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset tests whether the set contains all the items
+// of the `other` set.
+func (set *SortedSet[T]) IsSuperset(other *SortedSet[T]) bool {
+	return other.IsSubset(set)
+}
+
+// Equal tests whether both sets contain the same items
+func (set *SortedSet[T]) Equal(other *SortedSet[T]) bool {
+	return set.Size() == other.Size() && set.IsSubset(other)
+}
+
+// Disjoint tests whether the sets have no items in common
+func (set *SortedSet[T]) Disjoint(other *SortedSet[T]) bool {
+	for item := range set.Iterate {
+		if other.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON encodes the set as a JSON array of sorted items.
+// The value receiver makes sure the method is used also
+// for sets stored as value fields of marshaled structs.
+func (set SortedSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.ToSlice())
+}
+
+// UnmarshalJSON decodes the set from a JSON array.
+// Possible duplicate items are merged.
+func (set *SortedSet[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	*set = *NewSortedSet(items...)
+	return nil
+}
+
+func NewSortedSet[T cmp.Ordered](values ...T) *SortedSet[T] {
+	var ans SortedSet[T]
+	ans.testAndInit()
+	ans.tree.Add(values...)
+	return &ans
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedSetSimpleInit(t *testing.T) {
+	s := SortedSet[string]{}
+	assert.Equal(t, 0, s.Size())
+	assert.NotPanics(t, func() {
+		s.Remove("x")
+	})
+	_, ok := s.Min()
+	assert.False(t, ok)
+	s.Add("foo")
+	assert.Equal(t, 1, s.Size())
+}
+
+func TestSortedSetUniqueValues(t *testing.T) {
+	s := NewSortedSet("one", "two", "one")
+	assert.Equal(t, 2, s.Size())
+	s.Remove("one")
+	s.Remove("three")
+	assert.Equal(t, []string{"two"}, s.ToSlice())
+	assert.False(t, s.Contains("one"))
+	assert.True(t, s.Contains("two"))
+}
+
+func TestSortedSetOrder(t *testing.T) {
+	s := NewSortedSet(30, 10, 20, 50, 40)
+	assert.Equal(t, []int{10, 20, 30, 40, 50}, s.ToOrderedSlice())
+	vTest := make([]int, 0, 5)
+	for v := range s.Iterate {
+		vTest = append(vTest, v)
+	}
+	assert.Equal(t, []int{10, 20, 30, 40, 50}, vTest)
+}
+
+func TestSortedSetMinMaxPop(t *testing.T) {
+	s := NewSortedSet(30, 10, 20)
+	v, ok := s.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 10, v)
+	v, ok = s.Max()
+	assert.True(t, ok)
+	assert.Equal(t, 30, v)
+	v, ok = s.PopMin()
+	assert.True(t, ok)
+	assert.Equal(t, 10, v)
+	assert.Equal(t, []int{20, 30}, s.ToSlice())
+	s.PopMin()
+	s.PopMin()
+	_, ok = s.PopMin()
+	assert.False(t, ok)
+}
+
+func TestSortedSetRangeAndRank(t *testing.T) {
+	s := NewSortedSet(30, 10, 20, 50, 40)
+	vTest := make([]int, 0, 3)
+	for v := range s.Range(15, 40) {
+		vTest = append(vTest, v)
+	}
+	assert.Equal(t, []int{20, 30, 40}, vTest)
+	assert.Equal(t, 2, s.Rank(30))
+	assert.Equal(t, 5, s.Rank(100))
+}
+
+func TestSortedSetAlgebra(t *testing.T) {
+	s1 := NewSortedSet(1, 2, 3)
+	s2 := NewSortedSet(2, 3, 4)
+	assert.Equal(t, []int{1, 2, 3, 4}, s1.Union(s2).ToSlice())
+	assert.Equal(t, []int{1}, s1.Sub(s2).ToSlice())
+	assert.Equal(t, []int{2, 3}, s1.Intersect(s2).ToSlice())
+	assert.Equal(t, []int{1, 4}, s1.SymmetricDifference(s2).ToSlice())
+	assert.True(t, NewSortedSet(2, 3).IsSubset(s1))
+	assert.True(t, s1.IsSuperset(NewSortedSet(2, 3)))
+	assert.True(t, s1.Equal(NewSortedSet(3, 2, 1)))
+	assert.False(t, s1.Disjoint(s2))
+	assert.True(t, s1.Disjoint(NewSortedSet(7)))
+}
+
+func TestSortedSetJSON(t *testing.T) {
+	s := NewSortedSet("foo", "bar")
+	data, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, `["bar","foo"]`, string(data))
+	var s2 SortedSet[string]
+	err = json.Unmarshal([]byte(`["y", "x", "y"]`), &s2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"x", "y"}, s2.ToSlice())
+}
+
+func TestSortedSetJSONValueField(t *testing.T) {
+	type conf struct {
+		Ports SortedSet[int] `json:"ports"`
+	}
+	c := conf{Ports: *NewSortedSet(8080, 80)}
+	data, err := json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"ports":[80,8080]}`, string(data))
+
+	data, err = json.Marshal(conf{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"ports":[]}`, string(data))
+
+	var c2 conf
+	err = json.Unmarshal([]byte(`{"ports":[443,80]}`), &c2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{80, 443}, c2.Ports.ToSlice())
+}
+
+func TestSortedSetRemoveNaN(t *testing.T) {
+	s := NewSortedSet(1.0, math.NaN(), 2.0)
+	s.Add(math.NaN())
+	assert.Equal(t, 3, s.Size())
+	assert.True(t, s.Contains(math.NaN()))
+	s.Remove(math.NaN())
+	assert.Equal(t, 2, s.Size())
+	assert.False(t, s.Contains(math.NaN()))
+	assert.Equal(t, []float64{1, 2}, s.ToSlice())
+}
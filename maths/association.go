@@ -0,0 +1,182 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maths
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrInvalidContingencyTable = errors.New("invalid contingency table frequencies")
+
+	// ErrZeroCooccurrence is returned by ContingencyTable.Measures
+	// in case A and B never occur together (FreqAB == 0) which
+	// makes some of the measures infinite.
+	ErrZeroCooccurrence = errors.New("items do not co-occur")
+)
+
+// ContingencyTable represents co-occurrence frequencies of two items
+// A and B (e.g. a node word and a collocate) in a corpus of size N.
+// The frequencies define the following 2x2 table of observed values:
+//
+//	         B                       ¬B
+//	 A       FreqAB                  FreqA - FreqAB
+//	¬A       FreqB - FreqAB          N - FreqA - FreqB + FreqAB
+//
+// The individual measures expect a valid table (see Validate), otherwise
+// they may return infinite or NaN values. For FreqAB == 0 (a valid table),
+// MI, MI3, TScore and LogDice return -Inf, so callers should filter out
+// such items (Measures does this by returning ErrZeroCooccurrence).
+type ContingencyTable struct {
+	FreqAB int `json:"freqAB"`
+	FreqA  int `json:"freqA"`
+	FreqB  int `json:"freqB"`
+	N      int `json:"n"`
+}
+
+// Validate tests whether the frequencies are consistent
+// (FreqAB <= FreqA, FreqB and all the cells of the table are
+// non-negative) and whether the margins are not degenerate,
+// i.e. both A and B occur in the corpus and neither of them
+// fills the whole corpus (0 < FreqA, FreqB < N).
+func (ct ContingencyTable) Validate() error {
+	if ct.N <= 0 || ct.FreqA <= 0 || ct.FreqA >= ct.N || ct.FreqB <= 0 || ct.FreqB >= ct.N {
+		return ErrInvalidContingencyTable
+	}
+	if ct.FreqAB < 0 || ct.FreqAB > ct.FreqA || ct.FreqAB > ct.FreqB ||
+		ct.FreqA+ct.FreqB-ct.FreqAB > ct.N {
+		return ErrInvalidContingencyTable
+	}
+	return nil
+}
+
+// observed returns the observed frequencies O11, O12, O21, O22
+func (ct ContingencyTable) observed() [4]float64 {
+	fab, fa, fb, n := float64(ct.FreqAB), float64(ct.FreqA), float64(ct.FreqB), float64(ct.N)
+	return [4]float64{fab, fa - fab, fb - fab, n - fa - fb + fab}
+}
+
+// expected returns the expected frequencies E11, E12, E21, E22
+// under the hypothesis of independence of A and B
+func (ct ContingencyTable) expected() [4]float64 {
+	fa, fb, n := float64(ct.FreqA), float64(ct.FreqB), float64(ct.N)
+	return [4]float64{
+		fa * fb / n,
+		fa * (n - fb) / n,
+		(n - fa) * fb / n,
+		(n - fa) * (n - fb) / n,
+	}
+}
+
+// LogLikelihood calculates the log-likelihood ratio statistic G2
+// (Dunning, 1993). The value is always non-negative, i.e. it does
+// not distinguish between attraction and repulsion.
+func (ct ContingencyTable) LogLikelihood() float64 {
+	obs, exp := ct.observed(), ct.expected()
+	var ans float64
+	for i, o := range obs {
+		if o > 0 {
+			ans += o * math.Log(o/exp[i])
+		}
+	}
+	return 2 * ans
+}
+
+// MI calculates the pointwise mutual information (in bits)
+func (ct ContingencyTable) MI() float64 {
+	return math.Log2(ct.observed()[0] / ct.expected()[0])
+}
+
+// MI3 calculates the MI3 variant of the mutual information (Daille, 1994)
+// which reduces the MI preference for low-frequency pairs.
+func (ct ContingencyTable) MI3() float64 {
+	o11 := ct.observed()[0]
+	return math.Log2(o11 * o11 * o11 / ct.expected()[0])
+}
+
+// TScore calculates the t-score of the co-occurrence
+func (ct ContingencyTable) TScore() float64 {
+	o11 := ct.observed()[0]
+	return (o11 - ct.expected()[0]) / math.Sqrt(o11)
+}
+
+// Dice calculates the Dice coefficient
+func (ct ContingencyTable) Dice() float64 {
+	return 2 * float64(ct.FreqAB) / float64(ct.FreqA+ct.FreqB)
+}
+
+// LogDice calculates the logDice score (Rychlý, 2008) which has
+// a theoretical maximum of 14 (for items which always occur together).
+func (ct ContingencyTable) LogDice() float64 {
+	return 14 + math.Log2(ct.Dice())
+}
+
+// MinSensitivity calculates the minimum sensitivity, i.e. the smaller
+// of the conditional probabilities P(B|A) and P(A|B).
+func (ct ContingencyTable) MinSensitivity() float64 {
+	fab := float64(ct.FreqAB)
+	return min(fab/float64(ct.FreqA), fab/float64(ct.FreqB))
+}
+
+// ChiSquare calculates the Pearson's chi-square statistic
+// (without Yates' correction)
+func (ct ContingencyTable) ChiSquare() float64 {
+	obs, exp := ct.observed(), ct.expected()
+	var ans float64
+	for i, o := range obs {
+		ans += (o - exp[i]) * (o - exp[i]) / exp[i]
+	}
+	return ans
+}
+
+// AssociationMeasures contains all the association measures
+// available for a ContingencyTable
+type AssociationMeasures struct {
+	LogLikelihood  float64 `json:"logLikelihood"`
+	MI             float64 `json:"mi"`
+	MI3            float64 `json:"mi3"`
+	TScore         float64 `json:"tScore"`
+	Dice           float64 `json:"dice"`
+	LogDice        float64 `json:"logDice"`
+	MinSensitivity float64 `json:"minSensitivity"`
+	ChiSquare      float64 `json:"chiSquare"`
+}
+
+// Measures calculates all the available association measures.
+// In case the table is not valid, ErrInvalidContingencyTable
+// is returned. For FreqAB == 0, ErrZeroCooccurrence is returned
+// so the result always contains finite values (which is also
+// required for JSON encoding).
+func (ct ContingencyTable) Measures() (AssociationMeasures, error) {
+	if err := ct.Validate(); err != nil {
+		return AssociationMeasures{}, err
+	}
+	if ct.FreqAB == 0 {
+		return AssociationMeasures{}, ErrZeroCooccurrence
+	}
+	return AssociationMeasures{
+		LogLikelihood:  ct.LogLikelihood(),
+		MI:             ct.MI(),
+		MI3:            ct.MI3(),
+		TScore:         ct.TScore(),
+		Dice:           ct.Dice(),
+		LogDice:        ct.LogDice(),
+		MinSensitivity: ct.MinSensitivity(),
+		ChiSquare:      ct.ChiSquare(),
+	}, nil
+}
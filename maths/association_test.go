@@ -0,0 +1,136 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maths
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// reference values for the following tests are taken from
+// Manning & Schütze (1999), Foundations of Statistical Natural
+// Language Processing, chapter 5 (corpus of 14,307,668 tokens)
+
+func TestTScoreReference(t *testing.T) {
+	// "new companies"
+	ct := ContingencyTable{FreqAB: 8, FreqA: 15828, FreqB: 4675, N: 14307668}
+	assert.InDelta(t, 0.999932, ct.TScore(), 0.000001)
+}
+
+func TestChiSquareReference(t *testing.T) {
+	// "new companies"
+	ct := ContingencyTable{FreqAB: 8, FreqA: 15828, FreqB: 4675, N: 14307668}
+	assert.InDelta(t, 1.55, ct.ChiSquare(), 0.01)
+}
+
+func TestMIReference(t *testing.T) {
+	// "Ayatollah Ruhollah"
+	ct := ContingencyTable{FreqAB: 20, FreqA: 42, FreqB: 20, N: 14307668}
+	assert.InDelta(t, 18.38, ct.MI(), 0.01)
+	assert.InDelta(t, math.Log2(20*20*20*14307668.0/(42*20)), ct.MI3(), 1e-9)
+}
+
+func TestChiSquareEqualsShortcutFormula(t *testing.T) {
+	ct := ContingencyTable{FreqAB: 30, FreqA: 200, FreqB: 150, N: 10000}
+	o11, o12, o21, o22 := 30.0, 170.0, 120.0, 9680.0
+	exp := 10000 * math.Pow(o11*o22-o12*o21, 2) /
+		((o11 + o12) * (o21 + o22) * (o11 + o21) * (o12 + o22))
+	assert.InDelta(t, exp, ct.ChiSquare(), 1e-9)
+}
+
+func TestLogLikelihood(t *testing.T) {
+	ct := ContingencyTable{FreqAB: 30, FreqA: 200, FreqB: 150, N: 10000}
+	// G2 = 2 * N * (H(rows) + H(cols) - H(table)) with entropies in nats
+	h := func(vals ...float64) float64 {
+		var ans float64
+		for _, v := range vals {
+			ans -= v / 10000 * math.Log(v/10000)
+		}
+		return ans
+	}
+	exp := 2 * 10000 * (h(200, 9800) + h(150, 9850) - h(30, 170, 120, 9680))
+	assert.InDelta(t, exp, ct.LogLikelihood(), 1e-9)
+}
+
+func TestLogLikelihoodIndependence(t *testing.T) {
+	ct := ContingencyTable{FreqAB: 10, FreqA: 100, FreqB: 1000, N: 10000}
+	assert.InDelta(t, 0, ct.LogLikelihood(), 1e-9)
+	assert.InDelta(t, 0, ct.ChiSquare(), 1e-9)
+	assert.InDelta(t, 0, ct.MI(), 1e-9)
+}
+
+func TestDiceAndLogDice(t *testing.T) {
+	ct := ContingencyTable{FreqAB: 20, FreqA: 20, FreqB: 20, N: 1000000}
+	assert.Equal(t, 1.0, ct.Dice())
+	assert.Equal(t, 14.0, ct.LogDice())
+
+	ct = ContingencyTable{FreqAB: 10, FreqA: 40, FreqB: 120, N: 1000000}
+	assert.InDelta(t, 0.125, ct.Dice(), 1e-12)
+	assert.InDelta(t, 11.0, ct.LogDice(), 1e-12)
+}
+
+func TestMinSensitivity(t *testing.T) {
+	ct := ContingencyTable{FreqAB: 10, FreqA: 40, FreqB: 120, N: 1000000}
+	assert.InDelta(t, 10.0/120, ct.MinSensitivity(), 1e-12)
+}
+
+func TestMeasures(t *testing.T) {
+	ct := ContingencyTable{FreqAB: 8, FreqA: 15828, FreqB: 4675, N: 14307668}
+	m, err := ct.Measures()
+	assert.NoError(t, err)
+	assert.Equal(t, ct.TScore(), m.TScore)
+	assert.Equal(t, ct.LogDice(), m.LogDice)
+
+	_, err = ContingencyTable{FreqAB: 10, FreqA: 5, FreqB: 20, N: 100}.Measures()
+	assert.ErrorIs(t, err, ErrInvalidContingencyTable)
+	_, err = ContingencyTable{FreqAB: 1, FreqA: 60, FreqB: 60, N: 100}.Measures()
+	assert.ErrorIs(t, err, ErrInvalidContingencyTable)
+}
+
+func TestValidateDegenerateTables(t *testing.T) {
+	for _, ct := range []ContingencyTable{
+		{},
+		{FreqAB: 0, FreqA: 0, FreqB: 0, N: 100},
+		{FreqAB: 0, FreqA: 0, FreqB: 10, N: 100},
+		{FreqAB: 0, FreqA: 10, FreqB: 0, N: 100},
+		{FreqAB: 10, FreqA: 100, FreqB: 10, N: 100},
+		{FreqAB: 10, FreqA: 10, FreqB: 100, N: 100},
+		{FreqAB: 1, FreqA: 1, FreqB: 1, N: -1},
+	} {
+		assert.ErrorIs(t, ct.Validate(), ErrInvalidContingencyTable, "table %v", ct)
+	}
+	assert.NoError(t, ContingencyTable{FreqAB: 0, FreqA: 1, FreqB: 1, N: 2}.Validate())
+}
+
+func TestMeasuresZeroCooccurrence(t *testing.T) {
+	ct := ContingencyTable{FreqAB: 0, FreqA: 200, FreqB: 150, N: 10000}
+	assert.True(t, math.IsInf(ct.MI(), -1))
+	assert.True(t, ct.LogLikelihood() > 0)
+	_, err := ct.Measures()
+	assert.ErrorIs(t, err, ErrZeroCooccurrence)
+}
+
+func TestMeasuresJSON(t *testing.T) {
+	ct := ContingencyTable{FreqAB: 1, FreqA: 200, FreqB: 150, N: 10000}
+	m, err := ct.Measures()
+	assert.NoError(t, err)
+	_, err = json.Marshal(m)
+	assert.NoError(t, err)
+}
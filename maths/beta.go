@@ -0,0 +1,120 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maths
+
+import (
+	"math"
+)
+
+const (
+	betaCFMaxIter = 300
+	betaCFEps     = 1e-15
+	betaCFTiny    = 1e-300
+)
+
+// regIncBeta calculates the regularized incomplete beta function
+// I_x(a, b) using a continued fraction expansion (modified Lentz's method,
+// see Numerical Recipes, chapter 6.4).
+func regIncBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	lgab, _ := math.Lgamma(a + b)
+	front := math.Exp(lgab - lga - lgb + a*math.Log(x) + b*math.Log1p(-x))
+	// the continued fraction converges rapidly for x < (a+1)/(a+b+2),
+	// otherwise we use the symmetry I_x(a, b) = 1 - I_(1-x)(b, a)
+	if x < (a+1)/(a+b+2) {
+		return front * betaContFrac(x, a, b) / a
+	}
+	return 1 - front*betaContFrac(1-x, b, a)/b
+}
+
+func betaContFrac(x, a, b float64) float64 {
+	qab := a + b
+	qap := a + 1
+	qam := a - 1
+	c := 1.0
+	d := 1 - qab*x/qap
+	if math.Abs(d) < betaCFTiny {
+		d = betaCFTiny
+	}
+	d = 1 / d
+	ans := d
+	for m := 1; m <= betaCFMaxIter; m++ {
+		fm := float64(m)
+		m2 := 2 * fm
+		// even step
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < betaCFTiny {
+			d = betaCFTiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < betaCFTiny {
+			c = betaCFTiny
+		}
+		d = 1 / d
+		ans *= d * c
+		// odd step
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < betaCFTiny {
+			d = betaCFTiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < betaCFTiny {
+			c = betaCFTiny
+		}
+		d = 1 / d
+		del := d * c
+		ans *= del
+		if math.Abs(del-1) < betaCFEps {
+			break
+		}
+	}
+	return ans
+}
+
+// invertMonotonic finds x from [lo, hi] such that fn(x) = p for
+// a non-decreasing function fn using bisection. The interval must
+// contain the solution.
+func invertMonotonic(fn func(x float64) float64, p, lo, hi float64) float64 {
+	for i := 0; i < 200; i++ {
+		mid := lo + (hi-lo)/2
+		if mid == lo || mid == hi {
+			break
+		}
+		if fn(mid) < p {
+			lo = mid
+
+		} else {
+			hi = mid
+		}
+	}
+	return lo + (hi-lo)/2
+}
+
+// betaQuantile calculates the inverse of the cumulative distribution
+// function of the beta distribution Beta(a, b).
+func betaQuantile(p, a, b float64) float64 {
+	return invertMonotonic(func(x float64) float64 { return regIncBeta(x, a, b) }, p, 0, 1)
+}
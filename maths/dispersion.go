@@ -0,0 +1,194 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maths
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrInvalidDispersionInput = errors.New(
+		"frequencies and part sizes must be slices of the same length with non-negative frequencies and positive sizes")
+
+	ErrZeroFrequency = errors.New("total frequency must be positive")
+)
+
+// FreqsOf extracts frequencies from a slice of FreqInfo values
+// so they can be used with the dispersion functions.
+func FreqsOf[T FreqInfo](items []T) []int {
+	ans := make([]int, len(items))
+	for i, v := range items {
+		ans[i] = v.Freq()
+	}
+	return ans
+}
+
+// validateParts checks frequencies of an item in individual corpus
+// parts along with the sizes of the parts and returns total frequency
+// and total size.
+func validateParts(freqs, sizes []int) (int, int, error) {
+	if len(freqs) != len(sizes) {
+		return 0, 0, ErrInvalidDispersionInput
+	}
+	if len(freqs) < 2 {
+		return 0, 0, ErrTooSmallDataset
+	}
+	var totalFreq, totalSize int
+	for i, f := range freqs {
+		if f < 0 || sizes[i] <= 0 {
+			return 0, 0, ErrInvalidDispersionInput
+		}
+		totalFreq += f
+		totalSize += sizes[i]
+	}
+	if totalFreq == 0 {
+		return 0, 0, ErrZeroFrequency
+	}
+	return totalFreq, totalSize, nil
+}
+
+// DispersionCV calculates the coefficient of variation of relative
+// frequencies of an item in individual corpus parts. The `freqs` contains
+// frequencies of the item in the parts, `sizes` contains sizes of the parts.
+func DispersionCV(freqs, sizes []int) (float64, error) {
+	if _, _, err := validateParts(freqs, sizes); err != nil {
+		return 0, err
+	}
+	var mean OnlineMean
+	for i, f := range freqs {
+		mean = mean.Add(float64(f) / float64(sizes[i]))
+	}
+	// OnlineMean provides sample std. deviation but we need the population one
+	n := float64(len(freqs))
+	return mean.Stdev() * math.Sqrt((n-1)/n) / mean.Mean(), nil
+}
+
+// JuillandD calculates Juilland's D dispersion measure which ranges
+// from 0 (extremely uneven distribution) to 1 (perfectly even distribution).
+// Relative frequencies are used so the parts do not have to be of
+// the same size. For the arguments, see DispersionCV.
+func JuillandD(freqs, sizes []int) (float64, error) {
+	cv, err := DispersionCV(freqs, sizes)
+	if err != nil {
+		return 0, err
+	}
+	return 1 - cv/math.Sqrt(float64(len(freqs)-1)), nil
+}
+
+// GriesDP calculates Gries' DP ("deviation of proportions") which ranges
+// from 0 (distribution matching the sizes of the parts) to values close
+// to 1 (extremely uneven distribution). For the arguments, see DispersionCV.
+func GriesDP(freqs, sizes []int) (float64, error) {
+	totalFreq, totalSize, err := validateParts(freqs, sizes)
+	if err != nil {
+		return 0, err
+	}
+	var ans float64
+	for i, f := range freqs {
+		ans += math.Abs(float64(f)/float64(totalFreq) - float64(sizes[i])/float64(totalSize))
+	}
+	return ans / 2, nil
+}
+
+// GriesDPNorm calculates DP normalized to the [0, 1] interval
+// (Lijffijt & Gries, 2012). For the arguments, see DispersionCV.
+func GriesDPNorm(freqs, sizes []int) (float64, error) {
+	dp, err := GriesDP(freqs, sizes)
+	if err != nil {
+		return 0, err
+	}
+	minSize := sizes[0]
+	var totalSize int
+	for _, s := range sizes {
+		minSize = min(minSize, s)
+		totalSize += s
+	}
+	return dp / (1 - float64(minSize)/float64(totalSize)), nil
+}
+
+// RosengrenS calculates Rosengren's S dispersion measure, i.e. the ratio
+// of Rosengren's adjusted frequency and the total frequency. The value
+// ranges from 1/n (for n parts) to 1 (even distribution).
+// For the arguments, see DispersionCV.
+func RosengrenS(freqs, sizes []int) (float64, error) {
+	totalFreq, totalSize, err := validateParts(freqs, sizes)
+	if err != nil {
+		return 0, err
+	}
+	var sum float64
+	for i, f := range freqs {
+		sum += math.Sqrt(float64(sizes[i]) / float64(totalSize) * float64(f))
+	}
+	return sum * sum / float64(totalFreq), nil
+}
+
+// ARF calculates the average reduced frequency (Savický & Hlaváčová, 2002).
+// Unlike the other dispersion measures, it does not work with corpus parts
+// but with positions of all the occurrences of an item in a corpus of the
+// size `corpusSize`. The positions must be sorted. The value ranges from 1
+// (all the occurrences in a single cluster) to the frequency of the item
+// (perfectly even distribution).
+func ARF(positions []int, corpusSize int) (float64, error) {
+	if len(positions) == 0 {
+		return 0, ErrZeroFrequency
+	}
+	for i, p := range positions {
+		if p < 0 || p >= corpusSize || i > 0 && p < positions[i-1] {
+			return 0, ErrInvalidDispersionInput
+		}
+	}
+	v := float64(corpusSize) / float64(len(positions))
+	// the corpus is treated as cyclic so the first distance is
+	// between the last and the first occurrence
+	ans := math.Min(float64(corpusSize-positions[len(positions)-1]+positions[0]), v)
+	for i := 1; i < len(positions); i++ {
+		ans += math.Min(float64(positions[i]-positions[i-1]), v)
+	}
+	return ans / v, nil
+}
+
+// DispersionMeasures contains all the dispersion measures which
+// can be calculated from frequencies in corpus parts
+type DispersionMeasures struct {
+	CV          float64 `json:"cv"`
+	JuillandD   float64 `json:"juillandD"`
+	GriesDP     float64 `json:"griesDP"`
+	GriesDPNorm float64 `json:"griesDPNorm"`
+	RosengrenS  float64 `json:"rosengrenS"`
+}
+
+// Dispersion calculates all the dispersion measures available
+// for frequencies in corpus parts. For the arguments, see DispersionCV.
+func Dispersion(freqs, sizes []int) (DispersionMeasures, error) {
+	var ans DispersionMeasures
+	var err error
+	if ans.CV, err = DispersionCV(freqs, sizes); err != nil {
+		return DispersionMeasures{}, err
+	}
+	ans.JuillandD = 1 - ans.CV/math.Sqrt(float64(len(freqs)-1))
+	if ans.GriesDP, err = GriesDP(freqs, sizes); err != nil {
+		return DispersionMeasures{}, err
+	}
+	if ans.GriesDPNorm, err = GriesDPNorm(freqs, sizes); err != nil {
+		return DispersionMeasures{}, err
+	}
+	if ans.RosengrenS, err = RosengrenS(freqs, sizes); err != nil {
+		return DispersionMeasures{}, err
+	}
+	return ans, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maths
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type partFreq int
+
+func (pf partFreq) Freq() int {
+	return int(pf)
+}
+
+var (
+	testPartFreqs = []int{1, 2, 3, 4, 5}
+	testPartSizes = []int{10, 10, 10, 10, 10}
+)
+
+func TestFreqsOf(t *testing.T) {
+	assert.Equal(t, []int{3, 1}, FreqsOf([]partFreq{3, 1}))
+}
+
+func TestDispersionCV(t *testing.T) {
+	cv, err := DispersionCV(testPartFreqs, testPartSizes)
+	assert.NoError(t, err)
+	assert.InDelta(t, math.Sqrt(0.02)/0.3, cv, 1e-9)
+}
+
+func TestJuillandD(t *testing.T) {
+	d, err := JuillandD(testPartFreqs, testPartSizes)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1-math.Sqrt(0.02)/0.3/2, d, 1e-9)
+
+	d, err = JuillandD([]int{5, 10, 15}, []int{100, 200, 300})
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.0, d, 1e-9)
+}
+
+func TestGriesDP(t *testing.T) {
+	dp, err := GriesDP(testPartFreqs, testPartSizes)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.2, dp, 1e-9)
+
+	dpn, err := GriesDPNorm(testPartFreqs, testPartSizes)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.25, dpn, 1e-9)
+}
+
+func TestGriesDPExtremes(t *testing.T) {
+	dp, err := GriesDP([]int{5, 10, 15}, []int{100, 200, 300})
+	assert.NoError(t, err)
+	assert.InDelta(t, 0, dp, 1e-9)
+
+	dpn, err := GriesDPNorm([]int{7, 0, 0}, []int{100, 200, 300})
+	assert.NoError(t, err)
+	assert.InDelta(t, 1, dpn, 1e-9)
+}
+
+func TestRosengrenS(t *testing.T) {
+	s, err := RosengrenS(testPartFreqs, testPartSizes)
+	assert.NoError(t, err)
+	exp := math.Pow(math.Sqrt(0.2)*(1+math.Sqrt(2)+math.Sqrt(3)+2+math.Sqrt(5)), 2) / 15
+	assert.InDelta(t, exp, s, 1e-9)
+
+	s, err = RosengrenS([]int{0, 0, 0, 8}, []int{10, 10, 10, 10})
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.25, s, 1e-9)
+}
+
+func TestDispersionInvalidInput(t *testing.T) {
+	_, err := GriesDP([]int{1, 2}, []int{10})
+	assert.ErrorIs(t, err, ErrInvalidDispersionInput)
+	_, err = GriesDP([]int{1, 2}, []int{10, 0})
+	assert.ErrorIs(t, err, ErrInvalidDispersionInput)
+	_, err = JuillandD([]int{1}, []int{10})
+	assert.ErrorIs(t, err, ErrTooSmallDataset)
+	_, err = RosengrenS([]int{0, 0}, []int{10, 10})
+	assert.ErrorIs(t, err, ErrZeroFrequency)
+}
+
+func TestARF(t *testing.T) {
+	arf, err := ARF([]int{0, 25, 50, 75}, 100)
+	assert.NoError(t, err)
+	assert.InDelta(t, 4.0, arf, 1e-9)
+
+	arf, err = ARF([]int{0, 1, 2, 3}, 100)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.12, arf, 1e-9)
+
+	_, err = ARF([]int{}, 100)
+	assert.ErrorIs(t, err, ErrZeroFrequency)
+	_, err = ARF([]int{5, 1}, 100)
+	assert.ErrorIs(t, err, ErrInvalidDispersionInput)
+	_, err = ARF([]int{100}, 100)
+	assert.ErrorIs(t, err, ErrInvalidDispersionInput)
+}
+
+func TestDispersion(t *testing.T) {
+	m, err := Dispersion(testPartFreqs, testPartSizes)
+	assert.NoError(t, err)
+	d, _ := JuillandD(testPartFreqs, testPartSizes)
+	assert.InDelta(t, d, m.JuillandD, 1e-12)
+	assert.InDelta(t, 0.2, m.GriesDP, 1e-9)
+	assert.InDelta(t, 0.25, m.GriesDPNorm, 1e-9)
+
+	_, err = Dispersion([]int{0, 0}, []int{10, 10})
+	assert.ErrorIs(t, err, ErrZeroFrequency)
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maths
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/czcorpus/cnc-gokit/collections"
+)
+
+// logRatioZeroFreq is used instead of zero frequencies
+// when calculating the log ratio (Hardie, 2014)
+const logRatioZeroFreq = 0.5
+
+// FreqComparison contains frequencies of a single item (e.g. a word)
+// in a focus corpus and a reference corpus along with sizes
+// of the corpora. It provides keyness measures for the item.
+type FreqComparison struct {
+	FocusFreq int `json:"focusFreq"`
+	FocusSize int `json:"focusSize"`
+	RefFreq   int `json:"refFreq"`
+	RefSize   int `json:"refSize"`
+}
+
+func (fc FreqComparison) focusIPM() float64 {
+	return float64(fc.FocusFreq) / float64(fc.FocusSize) * 1e6
+}
+
+func (fc FreqComparison) refIPM() float64 {
+	return float64(fc.RefFreq) / float64(fc.RefSize) * 1e6
+}
+
+// PercentDiff calculates the %DIFF measure (Gabrielatos & Marchi, 2012),
+// i.e. the difference of normalized frequencies in percents of the reference
+// normalized frequency. For zero reference frequency, +Inf is returned
+// (or NaN in case both the frequencies are zero).
+func (fc FreqComparison) PercentDiff() float64 {
+	refIPM := fc.refIPM()
+	if refIPM == 0 {
+		if fc.FocusFreq == 0 {
+			return math.NaN()
+		}
+		return math.Inf(1)
+	}
+	return (fc.focusIPM() - refIPM) * 100 / refIPM
+}
+
+// SimpleMaths calculates the "simple maths" score (Kilgarriff, 2009),
+// i.e. the ratio of frequencies per million with the smoothing
+// parameter `n` added to both of them. Lower values of `n` (e.g. 1)
+// prefer rare items while higher values (e.g. 100 or 1000) prefer
+// more common ones.
+func (fc FreqComparison) SimpleMaths(n float64) float64 {
+	return (fc.focusIPM() + n) / (fc.refIPM() + n)
+}
+
+// LogRatio calculates the binary logarithm of the ratio of relative
+// frequencies (Hardie, 2014). Zero frequencies are replaced
+// by 0.5 to avoid infinite values. In case both the frequencies
+// are zero, NaN is returned.
+func (fc FreqComparison) LogRatio() float64 {
+	if fc.FocusFreq == 0 && fc.RefFreq == 0 {
+		return math.NaN()
+	}
+	f1 := float64(fc.FocusFreq)
+	if f1 == 0 {
+		f1 = logRatioZeroFreq
+	}
+	f2 := float64(fc.RefFreq)
+	if f2 == 0 {
+		f2 = logRatioZeroFreq
+	}
+	return math.Log2((f1 / float64(fc.FocusSize)) / (f2 / float64(fc.RefSize)))
+}
+
+// LogLikelihood calculates the log-likelihood keyness score
+// (Rayson & Garside, 2000). The value is always non-negative,
+// so to distinguish between positive and negative keywords,
+// see e.g. the LogRatio sign.
+func (fc FreqComparison) LogLikelihood() float64 {
+	f1, f2 := float64(fc.FocusFreq), float64(fc.RefFreq)
+	n1, n2 := float64(fc.FocusSize), float64(fc.RefSize)
+	e1 := n1 * (f1 + f2) / (n1 + n2)
+	e2 := n2 * (f1 + f2) / (n1 + n2)
+	var ans float64
+	if f1 > 0 {
+		ans += f1 * math.Log(f1/e1)
+	}
+	if f2 > 0 {
+		ans += f2 * math.Log(f2/e2)
+	}
+	return 2 * ans
+}
+
+// BayesFactor calculates the BIC approximation of the Bayes factor
+// (Wilson, 2013) based on the log-likelihood score. Values above 2
+// are considered positive evidence, above 6 strong evidence and
+// above 10 very strong evidence of a real difference.
+func (fc FreqComparison) BayesFactor() float64 {
+	return fc.LogLikelihood() - math.Log(float64(fc.FocusSize+fc.RefSize))
+}
+
+// KeynessMeasure is a function calculating a keyness score. Methods
+// of FreqComparison can be used directly (e.g. FreqComparison.LogRatio).
+type KeynessMeasure func(fc FreqComparison) float64
+
+// RankKeywords calculates the keyness `measure` for all the items found
+// in any of the frequency maps and returns them sorted by their scores
+// in descending order (items with NaN scores are placed at the end,
+// ties are sorted by keys).
+func RankKeywords[K cmp.Ordered](
+	focusFreqs map[K]int,
+	focusSize int,
+	refFreqs map[K]int,
+	refSize int,
+	measure KeynessMeasure,
+) []collections.MapEntry[K, float64] {
+	ans := make([]collections.MapEntry[K, float64], 0, len(focusFreqs))
+	process := func(k K) {
+		fc := FreqComparison{
+			FocusFreq: focusFreqs[k],
+			FocusSize: focusSize,
+			RefFreq:   refFreqs[k],
+			RefSize:   refSize,
+		}
+		ans = append(ans, collections.MapEntry[K, float64]{K: k, V: measure(fc)})
+	}
+	for k := range focusFreqs {
+		process(k)
+	}
+	for k := range refFreqs {
+		if _, ok := focusFreqs[k]; !ok {
+			process(k)
+		}
+	}
+	slices.SortFunc(ans, func(a, b collections.MapEntry[K, float64]) int {
+		if c := cmp.Compare(b.V, a.V); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.K, b.K)
+	})
+	return ans
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maths
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testFreqComparison() FreqComparison {
+	// 100 per million in focus vs. 25 per million in reference
+	return FreqComparison{FocusFreq: 100, FocusSize: 1000000, RefFreq: 50, RefSize: 2000000}
+}
+
+func TestPercentDiff(t *testing.T) {
+	assert.InDelta(t, 300.0, testFreqComparison().PercentDiff(), 1e-9)
+	fc := FreqComparison{FocusFreq: 10, FocusSize: 1000, RefFreq: 20, RefSize: 1000}
+	assert.InDelta(t, -50.0, fc.PercentDiff(), 1e-9)
+	fc.RefFreq = 0
+	assert.True(t, math.IsInf(fc.PercentDiff(), 1))
+	fc.FocusFreq = 0
+	assert.True(t, math.IsNaN(fc.PercentDiff()))
+}
+
+func TestSimpleMaths(t *testing.T) {
+	fc := testFreqComparison()
+	assert.InDelta(t, 101.0/26.0, fc.SimpleMaths(1), 1e-9)
+	assert.InDelta(t, 200.0/125.0, fc.SimpleMaths(100), 1e-9)
+}
+
+func TestLogRatio(t *testing.T) {
+	assert.InDelta(t, 2.0, testFreqComparison().LogRatio(), 1e-9)
+	fc := FreqComparison{FocusFreq: 8, FocusSize: 1000, RefFreq: 0, RefSize: 1000}
+	assert.InDelta(t, 4.0, fc.LogRatio(), 1e-9)
+}
+
+func TestKeynessLogLikelihood(t *testing.T) {
+	// E1 = 50, E2 = 100 => LL = 2 * (100 * ln(2) + 50 * ln(0.5))
+	assert.InDelta(t, 100*math.Ln2, testFreqComparison().LogLikelihood(), 1e-9)
+
+	fc := FreqComparison{FocusFreq: 0, FocusSize: 1000, RefFreq: 10, RefSize: 1000}
+	assert.InDelta(t, 2*10*math.Log(2), fc.LogLikelihood(), 1e-9)
+
+	fc = FreqComparison{FocusFreq: 10, FocusSize: 1000, RefFreq: 20, RefSize: 2000}
+	assert.InDelta(t, 0, fc.LogLikelihood(), 1e-9)
+}
+
+func TestBayesFactor(t *testing.T) {
+	fc := testFreqComparison()
+	assert.InDelta(t, 100*math.Ln2-math.Log(3000000), fc.BayesFactor(), 1e-9)
+}
+
+func TestRankKeywords(t *testing.T) {
+	focus := map[string]int{"foo": 100, "bar": 10, "baz": 10, "xyz": 0}
+	ref := map[string]int{"foo": 50, "bar": 20, "baz": 20, "abc": 40}
+	ans := RankKeywords(focus, 1000000, ref, 2000000, FreqComparison.LogRatio)
+	keys := make([]string, len(ans))
+	for i, v := range ans {
+		keys[i] = v.K
+	}
+	assert.Equal(t, []string{"foo", "bar", "baz", "abc", "xyz"}, keys)
+	assert.InDelta(t, 2.0, ans[0].V, 1e-9)
+	assert.InDelta(t, 0.0, ans[1].V, 1e-9)
+	assert.True(t, math.IsNaN(ans[4].V))
+}
+
+func TestRankKeywordsNaNLast(t *testing.T) {
+	focus := map[string]int{"foo": 0, "bar": 10}
+	ref := map[string]int{"foo": 0, "bar": 20}
+	ans := RankKeywords(focus, 1000, ref, 1000, FreqComparison.PercentDiff)
+	assert.Equal(t, "bar", ans[0].K)
+	assert.Equal(t, "foo", ans[1].K)
+	assert.True(t, math.IsNaN(ans[1].V))
+}
+
+func TestRankKeywordsCustomMeasure(t *testing.T) {
+	focus := map[int]int{1: 100, 2: 1}
+	ref := map[int]int{1: 50, 2: 0}
+	ans := RankKeywords(focus, 1000000, ref, 1000000, func(fc FreqComparison) float64 {
+		return fc.SimpleMaths(100)
+	})
+	assert.Equal(t, 1, ans[0].K)
+	assert.Equal(t, 2, ans[1].K)
+}
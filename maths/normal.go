@@ -0,0 +1,107 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maths
+
+import (
+	"math"
+)
+
+// coefficients of the rational approximation of the normal
+// quantile function by Peter J. Acklam
+var (
+	acklamA = [6]float64{
+		-3.969683028665376e+01, 2.209460984245205e+02, -2.759285104469687e+02,
+		1.383577518672690e+02, -3.066479806614716e+01, 2.506628277459239e+00,
+	}
+	acklamB = [5]float64{
+		-5.447609879822406e+01, 1.615858368580409e+02, -1.556989798598866e+02,
+		6.680131188771972e+01, -1.328068155288572e+01,
+	}
+	acklamC = [6]float64{
+		-7.784894002430293e-03, -3.223964580411365e-01, -2.400758277161838e+00,
+		-2.549732539343734e+00, 4.374664141464968e+00, 2.938163982698783e+00,
+	}
+	acklamD = [4]float64{
+		7.784695709041462e-03, 3.224671290700398e-01, 2.445134137142996e+00,
+		3.754408661907416e+00,
+	}
+)
+
+const acklamPLow = 0.02425
+
+// NormalPDF calculates the probability density function
+// of the normal distribution N(mu, sigma^2)
+func NormalPDF(x, mu, sigma float64) float64 {
+	z := (x - mu) / sigma
+	return math.Exp(-z*z/2) / (sigma * math.Sqrt(2*math.Pi))
+}
+
+// NormalCDF calculates the cumulative distribution function
+// of the normal distribution N(mu, sigma^2)
+func NormalCDF(x, mu, sigma float64) float64 {
+	return 0.5 * math.Erfc(-(x-mu)/(sigma*math.Sqrt2))
+}
+
+// standardNormalQuantile uses the Acklam's rational approximation
+// (relative error 1.15e-9) refined by a single step of the Halley's
+// method which brings the result to nearly full double precision.
+func standardNormalQuantile(p float64) float64 {
+	var x float64
+	if p < acklamPLow {
+		q := math.Sqrt(-2 * math.Log(p))
+		x = (((((acklamC[0]*q+acklamC[1])*q+acklamC[2])*q+acklamC[3])*q+acklamC[4])*q + acklamC[5]) /
+			((((acklamD[0]*q+acklamD[1])*q+acklamD[2])*q+acklamD[3])*q + 1)
+
+	} else if p <= 1-acklamPLow {
+		q := p - 0.5
+		r := q * q
+		x = (((((acklamA[0]*r+acklamA[1])*r+acklamA[2])*r+acklamA[3])*r+acklamA[4])*r + acklamA[5]) * q /
+			(((((acklamB[0]*r+acklamB[1])*r+acklamB[2])*r+acklamB[3])*r+acklamB[4])*r + 1)
+
+	} else {
+		q := math.Sqrt(-2 * math.Log1p(-p))
+		x = -(((((acklamC[0]*q+acklamC[1])*q+acklamC[2])*q+acklamC[3])*q+acklamC[4])*q + acklamC[5]) /
+			((((acklamD[0]*q+acklamD[1])*q+acklamD[2])*q+acklamD[3])*q + 1)
+	}
+	e := 0.5*math.Erfc(-x/math.Sqrt2) - p
+	u := e * math.Sqrt(2*math.Pi) * math.Exp(x*x/2)
+	return x - u/(1+x*u/2)
+}
+
+// NormalQuantile calculates the inverse of the cumulative distribution
+// function of the normal distribution N(mu, sigma^2), i.e. it returns
+// `x` such that P(X <= x) = p.
+// For p outside the (0, 1) interval, ErrInvalidProbability is returned.
+func NormalQuantile(p, mu, sigma float64) (float64, error) {
+	if !(p > 0 && p < 1) {
+		return 0, ErrInvalidProbability
+	}
+	return mu + sigma*standardNormalQuantile(p), nil
+}
+
+// ZValueTwoTail returns a critical value of the standard normal
+// distribution for a two-tailed test with the significance level
+// `alpha` from the (0, 1] interval (e.g. 1.959964 for alpha = 0.05).
+func ZValueTwoTail(alpha float64) (float64, error) {
+	if !(alpha > 0 && alpha <= 1) {
+		return 0, ErrInvalidProbability
+	}
+	if alpha == 1 {
+		return 0, nil
+	}
+	return standardNormalQuantile(1 - alpha/2), nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maths
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalPDF(t *testing.T) {
+	assert.InDelta(t, 0.3989422804, NormalPDF(0, 0, 1), 1e-10)
+	assert.InDelta(t, 0.2419707245, NormalPDF(1, 0, 1), 1e-10)
+	assert.InDelta(t, 0.1209853623, NormalPDF(12, 10, 2), 1e-10)
+}
+
+func TestNormalCDF(t *testing.T) {
+	assert.InDelta(t, 0.5, NormalCDF(0, 0, 1), 1e-15)
+	assert.InDelta(t, 0.9750021049, NormalCDF(1.96, 0, 1), 1e-10)
+	assert.InDelta(t, 0.1586552539, NormalCDF(8, 10, 2), 1e-10)
+}
+
+func TestNormalQuantile(t *testing.T) {
+	for _, p := range []float64{1e-10, 0.001, 0.02425, 0.1, 0.5, 0.8, 0.975, 0.999999} {
+		x, err := NormalQuantile(p, 0, 1)
+		assert.NoError(t, err)
+		assert.InDelta(t, p, NormalCDF(x, 0, 1), p*1e-12)
+	}
+	x, err := NormalQuantile(0.975, 100, 15)
+	assert.NoError(t, err)
+	assert.InDelta(t, 129.3995, x, 0.0001)
+
+	_, err = NormalQuantile(0, 0, 1)
+	assert.ErrorIs(t, err, ErrInvalidProbability)
+	_, err = NormalQuantile(math.NaN(), 0, 1)
+	assert.ErrorIs(t, err, ErrInvalidProbability)
+}
+
+func TestZValueTwoTail(t *testing.T) {
+	z, err := ZValueTwoTail(0.05)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.959964, z, 1e-6)
+
+	z, err = ZValueTwoTail(0.01)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.575829, z, 1e-6)
+
+	z, err = ZValueTwoTail(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, z)
+}
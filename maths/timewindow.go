@@ -0,0 +1,161 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maths
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/czcorpus/cnc-gokit/collections"
+)
+
+var ErrInvalidPercentile = errors.New("percentile must be in the [0, 100] range")
+
+// TimedValue is a value with a time information
+type TimedValue struct {
+	Time  time.Time
+	Value float64
+}
+
+// TimeWindow keeps values from a sliding time window (e.g. the last
+// 10 minutes) and provides basic statistics over them. The values are
+// stored in a collections.CircularList so the maximum number of items
+// is limited by the capacity specified in NewTimeWindow. Once the capacity
+// is depleted, the oldest items are replaced even if they are still within
+// the time window.
+// The values are expected to be added in chronological order.
+type TimeWindow struct {
+	items  *collections.CircularList[TimedValue]
+	window time.Duration
+}
+
+// Add inserts a new value and removes all the values older
+// than t - window.
+func (tw *TimeWindow) Add(t time.Time, v float64) {
+	tw.items.Append(TimedValue{Time: t, Value: v})
+	tw.EvictBefore(t.Add(-tw.window))
+}
+
+// EvictBefore removes all the values with time before `t`.
+// This is useful e.g. in case stats are requested for the current
+// time but no value has been added for a longer time.
+func (tw *TimeWindow) EvictBefore(t time.Time) {
+	tw.items.DeleteWhile(func(item TimedValue) bool {
+		return item.Time.Before(t)
+	})
+}
+
+// Iterate goes through all the values in the window from
+// the oldest to the newest one.
+func (tw *TimeWindow) Iterate(yield func(i int, item TimedValue) bool) {
+	tw.items.IterateLogical(yield)
+}
+
+// Count returns number of values in the window
+func (tw *TimeWindow) Count() int {
+	return tw.items.Len()
+}
+
+// Sum returns sum of all the values in the window
+func (tw *TimeWindow) Sum() float64 {
+	var ans float64
+	for _, item := range tw.items.IterateLogical {
+		ans += item.Value
+	}
+	return ans
+}
+
+// OnlineMean returns mean and std. deviation info
+// for all the values in the window.
+func (tw *TimeWindow) OnlineMean() OnlineMean {
+	var ans OnlineMean
+	for _, item := range tw.items.IterateLogical {
+		ans = ans.Add(item.Value)
+	}
+	return ans
+}
+
+// Mean returns mean of all the values in the window (or zero
+// for an empty window).
+func (tw *TimeWindow) Mean() float64 {
+	return tw.OnlineMean().Mean()
+}
+
+// Stdev returns sample std. deviation of all the values
+// in the window.
+func (tw *TimeWindow) Stdev() float64 {
+	return tw.OnlineMean().Stdev()
+}
+
+// Min returns the lowest value in the window. For an empty window,
+// ErrTooSmallDataset is returned.
+func (tw *TimeWindow) Min() (float64, error) {
+	if tw.items.Len() == 0 {
+		return 0, ErrTooSmallDataset
+	}
+	ans := math.Inf(1)
+	for _, item := range tw.items.IterateLogical {
+		ans = math.Min(ans, item.Value)
+	}
+	return ans, nil
+}
+
+// Max returns the greatest value in the window. For an empty window,
+// ErrTooSmallDataset is returned.
+func (tw *TimeWindow) Max() (float64, error) {
+	if tw.items.Len() == 0 {
+		return 0, ErrTooSmallDataset
+	}
+	ans := math.Inf(-1)
+	for _, item := range tw.items.IterateLogical {
+		ans = math.Max(ans, item.Value)
+	}
+	return ans, nil
+}
+
+// Percentile returns p-th percentile (0 <= p <= 100) of the values
+// in the window. Linear interpolation between closest ranks is used.
+// For an empty window, ErrTooSmallDataset is returned.
+func (tw *TimeWindow) Percentile(p float64) (float64, error) {
+	if !(p >= 0 && p <= 100) { // note: also rejects NaN
+		return 0, ErrInvalidPercentile
+	}
+	if tw.items.Len() == 0 {
+		return 0, ErrTooSmallDataset
+	}
+	values := make([]float64, 0, tw.items.Len())
+	for _, item := range tw.items.IterateLogical {
+		values = append(values, item.Value)
+	}
+	slices.Sort(values)
+	rank := p / 100 * float64(len(values)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return values[lo] + (values[hi]-values[lo])*(rank-float64(lo)), nil
+}
+
+// NewTimeWindow creates a new TimeWindow for values not older
+// than `window` (relative to the most recent value). The `capacity`
+// specifies the maximum number of values kept in the window.
+func NewTimeWindow(window time.Duration, capacity int) *TimeWindow {
+	return &TimeWindow{
+		items:  collections.NewCircularList[TimedValue](capacity),
+		window: window,
+	}
+}
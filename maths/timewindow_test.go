@@ -0,0 +1,100 @@
+// Copyright 2025 Tomas Machalek <tomas.machalek@gmail.com>
+// Copyright 2025 Institute of the Czech National Corpus,
+//                Faculty of Arts, Charles University
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maths
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeWindowEvictsOnAdd(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tw := NewTimeWindow(time.Minute, 100)
+	tw.Add(t0, 1)
+	tw.Add(t0.Add(30*time.Second), 2)
+	tw.Add(t0.Add(60*time.Second), 3)
+	assert.Equal(t, 3, tw.Count())
+	tw.Add(t0.Add(61*time.Second), 4)
+	assert.Equal(t, 3, tw.Count())
+	assert.Equal(t, 9.0, tw.Sum())
+	assert.Equal(t, 3.0, tw.Mean())
+	assert.InDelta(t, 1.0, tw.Stdev(), 0.00001)
+}
+
+func TestTimeWindowEvictBefore(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tw := NewTimeWindow(time.Minute, 100)
+	tw.Add(t0, 1)
+	tw.Add(t0.Add(30*time.Second), 2)
+	tw.EvictBefore(t0.Add(time.Hour))
+	assert.Equal(t, 0, tw.Count())
+	assert.Equal(t, 0.0, tw.Mean())
+}
+
+func TestTimeWindowCapacity(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tw := NewTimeWindow(time.Hour, 3)
+	for i := 0; i < 5; i++ {
+		tw.Add(t0.Add(time.Duration(i)*time.Second), float64(i))
+	}
+	assert.Equal(t, 3, tw.Count())
+	assert.Equal(t, 9.0, tw.Sum())
+}
+
+func TestTimeWindowMinMax(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tw := NewTimeWindow(time.Minute, 100)
+	_, err := tw.Min()
+	assert.ErrorIs(t, err, ErrTooSmallDataset)
+	_, err = tw.Max()
+	assert.ErrorIs(t, err, ErrTooSmallDataset)
+	for i, v := range []float64{3, -1, 7, 2} {
+		tw.Add(t0.Add(time.Duration(i)*time.Second), v)
+	}
+	minVal, err := tw.Min()
+	assert.NoError(t, err)
+	assert.Equal(t, -1.0, minVal)
+	maxVal, err := tw.Max()
+	assert.NoError(t, err)
+	assert.Equal(t, 7.0, maxVal)
+}
+
+func TestTimeWindowPercentile(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tw := NewTimeWindow(time.Minute, 100)
+	_, err := tw.Percentile(50)
+	assert.ErrorIs(t, err, ErrTooSmallDataset)
+	for i, v := range []float64{15, 20, 35, 40, 50} {
+		tw.Add(t0.Add(time.Duration(i)*time.Second), v)
+	}
+	p, err := tw.Percentile(50)
+	assert.NoError(t, err)
+	assert.Equal(t, 35.0, p)
+	p, err = tw.Percentile(40)
+	assert.NoError(t, err)
+	assert.InDelta(t, 29.0, p, 0.00001)
+	p, err = tw.Percentile(100)
+	assert.NoError(t, err)
+	assert.Equal(t, 50.0, p)
+	_, err = tw.Percentile(101)
+	assert.ErrorIs(t, err, ErrInvalidPercentile)
+	_, err = tw.Percentile(math.NaN())
+	assert.ErrorIs(t, err, ErrInvalidPercentile)
+}
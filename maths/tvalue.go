@@ -19,52 +19,122 @@ package maths
 
 import (
 	"math"
+	"strconv"
 )
 
 type SignificanceLevel string
 
-func findTValue(df int, ci SignificanceLevel) (float64, error) {
-	if df == 0 {
+// Alpha returns the significance level as a number
+func (sl SignificanceLevel) Alpha() (float64, error) {
+	ans, err := strconv.ParseFloat(string(sl), 64)
+	if err != nil || !(ans > 0 && ans <= 1) {
+		return 0, ErrUnsupportedSignifLevel
+	}
+	return ans, nil
+}
+
+// StudentTPDF calculates the probability density function
+// of the Student's t-distribution with `df` degrees of freedom.
+func StudentTPDF(t, df float64) float64 {
+	lg1, _ := math.Lgamma((df + 1) / 2)
+	lg2, _ := math.Lgamma(df / 2)
+	return math.Exp(lg1-lg2-0.5*math.Log(df*math.Pi)) *
+		math.Pow(1+t*t/df, -(df+1)/2)
+}
+
+// StudentTCDF calculates the cumulative distribution function
+// of the Student's t-distribution with `df` degrees of freedom
+// (which does not have to be an integer). The function
+// is calculated via the regularized incomplete beta function.
+func StudentTCDF(t, df float64) float64 {
+	tail := 0.5 * regIncBeta(df/(df+t*t), df/2, 0.5)
+	if t > 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+// StudentTQuantile calculates the inverse of the cumulative distribution
+// function of the Student's t-distribution with `df` degrees of freedom,
+// i.e. it returns `t` such that P(T <= t) = p.
+// For p outside the (0, 1) interval, ErrInvalidProbability is returned,
+// for non-positive df, ErrValueNotAvailable is returned.
+func StudentTQuantile(p, df float64) (float64, error) {
+	if df <= 0 {
+		return 0, ErrValueNotAvailable
+	}
+	if !(p > 0 && p < 1) {
+		return 0, ErrInvalidProbability
+	}
+	if p == 0.5 {
+		return 0, nil
+	}
+	if p < 0.5 {
+		ans, err := StudentTQuantile(1-p, df)
+		return -ans, err
+	}
+	hi := 1.0
+	for StudentTCDF(hi, df) < p {
+		hi *= 2
+	}
+	return invertMonotonic(func(x float64) float64 { return StudentTCDF(x, df) }, p, 0, hi), nil
+}
+
+// TValueTwoTailAlpha returns a critical t-value for a two-tailed test
+// with the significance level `alpha` from the (0, 1] interval
+// (e.g. 0.05 for 95% confidence) and `df` degrees of freedom.
+func TValueTwoTailAlpha(df int, alpha float64) (float64, error) {
+	if df <= 0 {
 		return 0, ErrValueNotAvailable
 	}
-	col := idxMap[ci]
-	if df <= 30 {
-		return tTable[df][col], nil
+	if !(alpha > 0 && alpha <= 1) {
+		return 0, ErrInvalidProbability
 	}
-	dist := 100000.0
-	match := 0
-	for dfx := range tTable {
-		currDist := math.Abs(float64(df) - float64(dfx))
-		if currDist < dist {
-			match = dfx
-			dist = currDist
-		}
+	if alpha == 1 {
+		return 0, nil
 	}
-	return tTable[match][col], nil
+	return StudentTQuantile(1-alpha/2, float64(df))
+}
+
+// TDistribConfIntervalAlpha calculates a confidence interval
+// for a sample mean and standard deviation in case
+// population std. deviation is unknown and the values
+// are "roughly normal".
+// The significance level `alpha` (e.g. 0.05 for 95% confidence)
+// is always applied in "two tails" mode.
+func TDistribConfIntervalAlpha(mean, stdev float64, sampleSize int, alpha float64) (float64, float64, error) {
+	tVal, err := TValueTwoTailAlpha(sampleSize-1, alpha)
+	if err != nil {
+		return 0, 0, err
+	}
+	lft := mean - tVal*stdev/math.Sqrt(float64(sampleSize))
+	rgt := mean + tVal*stdev/math.Sqrt(float64(sampleSize))
+	return lft, rgt, nil
 }
 
 // TDistribConfInterval calculates a confidence interval
 // for a sample mean and standard deviation in case
 // population std. deviation is unknown and the values
 // are "roughly normal".
-// Please note that the function calculates respective
-// t-values using a simple lookup table and is reliable
-// up to 100 degrees of freedom. Higher values will be
-// likely approximated by 1000 df which may or may not
-// serve well.
 // The provided confidence level is always applied
 // in "two tails" mode.
+// This is a variant of TDistribConfIntervalAlpha for predefined
+// significance levels.
 func TDistribConfInterval(mean, stdev float64, sampleSize int, conf SignificanceLevel) (float64, float64, error) {
-	tVal, err := findTValue(sampleSize-1, conf)
+	alpha, err := conf.Alpha()
 	if err != nil {
 		return 0, 0, err
 	}
-	lft := mean - tVal*stdev/math.Sqrt(float64(sampleSize))
-	rgt := mean + tVal*stdev/math.Sqrt(float64(sampleSize))
-	return lft, rgt, nil
+	return TDistribConfIntervalAlpha(mean, stdev, sampleSize, alpha)
 }
 
-// TValueTwoTail gets t-value with two-tailed confidence level
+// TValueTwoTail gets t-value with two-tailed confidence level.
+// This is a variant of TValueTwoTailAlpha for predefined
+// significance levels.
 func TValueTwoTail(df int, conf SignificanceLevel) (float64, error) {
-	return findTValue(df, conf)
+	alpha, err := conf.Alpha()
+	if err != nil {
+		return 0, err
+	}
+	return TValueTwoTailAlpha(df, alpha)
 }
@@ -17,6 +17,7 @@
 package maths
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -41,30 +42,94 @@ func TestTValueTwoTailErr(t *testing.T) {
 	assert.ErrorIs(t, ErrValueNotAvailable, err)
 }
 
-func TestTValueTwoTail34Goes30(t *testing.T) {
+func TestTValueTwoTailNoTableSnapping(t *testing.T) {
 	tv, err := TValueTwoTail(34, Significance_0_05)
-	tv2, err2 := TValueTwoTail(30, Significance_0_05)
 	assert.NoError(t, err)
-	assert.NoError(t, err2)
-	assert.True(t, tv == tv2)
-}
+	assert.InDelta(t, 2.0322, tv, 0.0001)
+
+	tv, err = TValueTwoTail(45, Significance_0_05)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.0141, tv, 0.0001)
 
-func TestTValueTwoTail48Goes60(t *testing.T) {
-	tv, err := TValueTwoTail(51, Significance_0_05)
-	tv2, err2 := TValueTwoTail(60, Significance_0_05)
+	tv, err = TValueTwoTail(51, Significance_0_05)
 	assert.NoError(t, err)
-	assert.NoError(t, err2)
-	assert.True(t, tv == tv2)
+	assert.InDelta(t, 2.0076, tv, 0.0001)
 }
 
 func TestTValueTwoTailLarge(t *testing.T) {
 	tv, err := TValueTwoTail(400, Significance_0_05)
 	assert.NoError(t, err)
-	assert.InDelta(t, 1.984, tv, 0.001)
+	assert.InDelta(t, 1.9659, tv, 0.0001)
+
+	tv, err = TValueTwoTail(100000, Significance_0_05)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.9600, tv, 0.0001)
+}
+
+func TestTValueTwoTailOne(t *testing.T) {
+	tv, err := TValueTwoTail(10, Significance_1_00)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, tv)
+}
+
+func TestTValueTwoTailUnsupportedLevel(t *testing.T) {
+	_, err := TValueTwoTail(10, SignificanceLevel("foo"))
+	assert.ErrorIs(t, err, ErrUnsupportedSignifLevel)
+}
+
+func TestTValueTwoTailAlpha(t *testing.T) {
+	tv, err := TValueTwoTailAlpha(10, 0.05)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.2281, tv, 0.0001)
+
+	tv, err = TValueTwoTailAlpha(20, 0.07)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.9143, tv, 0.0001)
+
+	_, err = TValueTwoTailAlpha(10, 0)
+	assert.ErrorIs(t, err, ErrInvalidProbability)
+	_, err = TValueTwoTailAlpha(0, 0.05)
+	assert.ErrorIs(t, err, ErrValueNotAvailable)
+}
+
+func TestStudentTCDF(t *testing.T) {
+	assert.InDelta(t, 0.5, StudentTCDF(0, 5), 1e-12)
+	assert.InDelta(t, 0.75, StudentTCDF(1, 1), 1e-10) // Cauchy distribution
+	assert.InDelta(t, 0.975, StudentTCDF(2.228139, 10), 1e-6)
+	assert.InDelta(t, 0.025, StudentTCDF(-2.228139, 10), 1e-6)
+	assert.InDelta(t, 0.948830, StudentTCDF(2.527977, 2.5), 1e-6)
+}
+
+func TestStudentTPDF(t *testing.T) {
+	assert.InDelta(t, 1/math.Pi, StudentTPDF(0, 1), 1e-12)
+	assert.InDelta(t, 0.3796067, StudentTPDF(0, 5), 1e-6)
 }
 
-func TestTValueTwoTailLarge2(t *testing.T) {
-	tv, err := TValueTwoTail(600, Significance_0_05)
+func TestStudentTQuantile(t *testing.T) {
+	q, err := StudentTQuantile(0.975, 1)
+	assert.NoError(t, err)
+	assert.InDelta(t, 12.7062, q, 0.0001)
+
+	q, err = StudentTQuantile(0.995, 2)
+	assert.NoError(t, err)
+	assert.InDelta(t, 9.9248, q, 0.0001)
+
+	q, err = StudentTQuantile(0.05, 15)
+	assert.NoError(t, err)
+	assert.InDelta(t, -1.7531, q, 0.0001)
+
+	_, err = StudentTQuantile(1, 15)
+	assert.ErrorIs(t, err, ErrInvalidProbability)
+}
+
+func TestTDistribConfIntervalAlpha(t *testing.T) {
+	lft, rgt, err := TDistribConfIntervalAlpha(10, 2, 25, 0.05)
+	assert.NoError(t, err)
+	assert.InDelta(t, 10-2.0639*2/5, lft, 0.0001)
+	assert.InDelta(t, 10+2.0639*2/5, rgt, 0.0001)
+
+	lft2, rgt2, err := TDistribConfInterval(10, 2, 25, Significance_0_05)
 	assert.NoError(t, err)
-	assert.InDelta(t, 1.962, tv, 0.001)
+	assert.Equal(t, lft, lft2)
+	assert.Equal(t, rgt, rgt2)
 }
@@ -17,19 +17,120 @@
 
 package maths
 
-import "math"
+import (
+	"errors"
+	"math"
+)
 
-// WilsonCI calculates Wilson confidence interval for a random
+var ErrInvalidSuccCount = errors.New("number of successes must be from the [0, sampleSize] interval")
+
+func validateBinomialArgs(succ float64, sampleSize int) error {
+	if sampleSize < 1 {
+		return ErrTooSmallDataset
+	}
+	if !(succ >= 0 && succ <= float64(sampleSize)) {
+		return ErrInvalidSuccCount
+	}
+	return nil
+}
+
+// WilsonCIAlpha calculates Wilson confidence interval for a random
 // variable with binomial distribution. The input arguments
 // are represented as: `succ` successful trials out of `sampleSize`
-func WilsonCI(succ float64, sampleSize int, signif SignificanceLevel) (float64, float64, error) {
-	z, ok := zTable[signif]
-	if !ok {
-		return 0, 0, ErrUnsupportedSignifLevel
-	}
-	p := succ / float64(sampleSize)
-	sq := z * math.Sqrt(p*(1-p)/float64(sampleSize)+math.Pow(z, 2)/(4*math.Pow(float64(sampleSize), 2)))
-	denom := 1 + math.Pow(z, 2)/float64(sampleSize)
-	a := p + math.Pow(z, 2)/(2*float64(sampleSize))
+// and a significance level `alpha` from the (0, 1] interval
+// (e.g. 0.05 for 95% confidence).
+func WilsonCIAlpha(succ float64, sampleSize int, alpha float64) (float64, float64, error) {
+	if err := validateBinomialArgs(succ, sampleSize); err != nil {
+		return 0, 0, err
+	}
+	z, err := ZValueTwoTail(alpha)
+	if err != nil {
+		return 0, 0, err
+	}
+	n := float64(sampleSize)
+	p := succ / n
+	sq := z * math.Sqrt(p*(1-p)/n+math.Pow(z, 2)/(4*math.Pow(n, 2)))
+	denom := 1 + math.Pow(z, 2)/n
+	a := p + math.Pow(z, 2)/(2*n)
 	return (a - sq) / denom, (a + sq) / denom, nil
 }
+
+// WilsonCI calculates Wilson confidence interval for a random
+// variable with binomial distribution. The input arguments
+// are represented as: `succ` successful trials out of `sampleSize`.
+// This is a variant of WilsonCIAlpha for predefined significance levels.
+func WilsonCI(succ float64, sampleSize int, signif SignificanceLevel) (float64, float64, error) {
+	alpha, err := signif.Alpha()
+	if err != nil {
+		return 0, 0, err
+	}
+	return WilsonCIAlpha(succ, sampleSize, alpha)
+}
+
+// WilsonCICorrected calculates Wilson confidence interval with continuity
+// correction (Newcombe, 1998) which is more conservative and better suited
+// for small counts. For the arguments, see WilsonCIAlpha.
+func WilsonCICorrected(succ float64, sampleSize int, alpha float64) (float64, float64, error) {
+	if err := validateBinomialArgs(succ, sampleSize); err != nil {
+		return 0, 0, err
+	}
+	z, err := ZValueTwoTail(alpha)
+	if err != nil {
+		return 0, 0, err
+	}
+	n := float64(sampleSize)
+	p := succ / n
+	z2 := z * z
+	denom := 2 * (n + z2)
+	lft := 0.0
+	if succ > 0 {
+		lft = (2*n*p + z2 - 1 - z*math.Sqrt(z2-2-1/n+4*p*(n*(1-p)+1))) / denom
+		lft = max(0, lft)
+	}
+	rgt := 1.0
+	if succ < n {
+		rgt = (2*n*p + z2 + 1 + z*math.Sqrt(z2+2-1/n+4*p*(n*(1-p)-1))) / denom
+		rgt = min(1, rgt)
+	}
+	return lft, rgt, nil
+}
+
+// ClopperPearsonCI calculates the "exact" Clopper-Pearson confidence
+// interval based on quantiles of the beta distribution. It guarantees
+// the coverage at the cost of being rather conservative.
+// For the arguments, see WilsonCIAlpha.
+func ClopperPearsonCI(succ float64, sampleSize int, alpha float64) (float64, float64, error) {
+	if err := validateBinomialArgs(succ, sampleSize); err != nil {
+		return 0, 0, err
+	}
+	if !(alpha > 0 && alpha <= 1) {
+		return 0, 0, ErrInvalidProbability
+	}
+	n := float64(sampleSize)
+	lft := 0.0
+	if succ > 0 {
+		lft = betaQuantile(alpha/2, succ, n-succ+1)
+	}
+	rgt := 1.0
+	if succ < n {
+		rgt = betaQuantile(1-alpha/2, succ+1, n-succ)
+	}
+	return lft, rgt, nil
+}
+
+// AgrestiCoullCI calculates the Agresti-Coull ("adjusted Wald")
+// confidence interval. The result is clipped to the [0, 1] interval.
+// For the arguments, see WilsonCIAlpha.
+func AgrestiCoullCI(succ float64, sampleSize int, alpha float64) (float64, float64, error) {
+	if err := validateBinomialArgs(succ, sampleSize); err != nil {
+		return 0, 0, err
+	}
+	z, err := ZValueTwoTail(alpha)
+	if err != nil {
+		return 0, 0, err
+	}
+	n := float64(sampleSize) + z*z
+	p := (succ + z*z/2) / n
+	sq := z * math.Sqrt(p*(1-p)/n)
+	return max(0, p-sq), min(1, p+sq), nil
+}
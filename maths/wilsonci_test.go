@@ -17,6 +17,7 @@
 package maths
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -29,3 +30,80 @@ func TestWilsonCI(t *testing.T) {
 	assert.InDelta(t, 0.5323, rgt, 0.001)
 	assert.NoError(t, err)
 }
+
+func TestWilsonCIUnsupportedLevel(t *testing.T) {
+	_, _, err := WilsonCI(3, 12, SignificanceLevel("0.x"))
+	assert.ErrorIs(t, err, ErrUnsupportedSignifLevel)
+}
+
+func TestWilsonCIAlpha(t *testing.T) {
+	lft, rgt, err := WilsonCIAlpha(3, 12, 0.05)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.08894, lft, 0.0001)
+	assert.InDelta(t, 0.53231, rgt, 0.0001)
+
+	lft, rgt, err = WilsonCIAlpha(3, 12, 0.1)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.10465, lft, 0.0001)
+	assert.InDelta(t, 0.48734, rgt, 0.0001)
+
+	// Newcombe (1998), Table I
+	lft, rgt, err = WilsonCIAlpha(81, 263, 0.05)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.2553, lft, 0.0001)
+	assert.InDelta(t, 0.3662, rgt, 0.0001)
+
+	_, _, err = WilsonCIAlpha(3, 12, 0)
+	assert.ErrorIs(t, err, ErrInvalidProbability)
+	_, _, err = WilsonCIAlpha(13, 12, 0.05)
+	assert.ErrorIs(t, err, ErrInvalidSuccCount)
+	_, _, err = WilsonCIAlpha(0, 0, 0.05)
+	assert.ErrorIs(t, err, ErrTooSmallDataset)
+}
+
+// reference values taken from Newcombe (1998), Two-sided confidence
+// intervals for the single proportion, Table I
+func TestWilsonCICorrected(t *testing.T) {
+	lft, rgt, err := WilsonCICorrected(0, 10, 0.05)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, lft)
+	assert.InDelta(t, 0.3445, rgt, 0.0001)
+
+	lft, rgt, err = WilsonCICorrected(1, 29, 0.05)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.0018, lft, 0.0001)
+	assert.InDelta(t, 0.1963, rgt, 0.0001)
+
+	lft, rgt, err = WilsonCICorrected(81, 263, 0.05)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.2535, lft, 0.0001)
+	assert.InDelta(t, 0.3682, rgt, 0.0001)
+}
+
+func TestClopperPearsonCI(t *testing.T) {
+	lft, rgt, err := ClopperPearsonCI(3, 12, 0.05)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.05486, lft, 0.0001)
+	assert.InDelta(t, 0.57186, rgt, 0.0001)
+
+	lft, rgt, err = ClopperPearsonCI(0, 10, 0.05)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, lft)
+	assert.InDelta(t, 1-math.Pow(0.025, 0.1), rgt, 1e-9)
+
+	lft, rgt, err = ClopperPearsonCI(10, 10, 0.05)
+	assert.NoError(t, err)
+	assert.InDelta(t, math.Pow(0.025, 0.1), lft, 1e-9)
+	assert.Equal(t, 1.0, rgt)
+}
+
+func TestAgrestiCoullCI(t *testing.T) {
+	lft, rgt, err := AgrestiCoullCI(3, 12, 0.05)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.08275, lft, 0.0001)
+	assert.InDelta(t, 0.53850, rgt, 0.0001)
+
+	lft, _, err = AgrestiCoullCI(0, 12, 0.05)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, lft)
+}